@@ -32,6 +32,9 @@ func main() {
 	// 	&models.DMInputPairwise{},
 	// 	&models.DMInputDirectWeight{},
 	// 	&models.ResultRanking{},
+	// 	&models.ProjectRound{},
+	// 	&models.CalculationTrace{},
+	// 	&models.CalculationRun{},
 	// )
 	// if err != nil {
 	// 	log.Fatal("Failed to Migrate Database")
@@ -42,6 +45,134 @@ func main() {
 	db.Exec("ALTER TABLE criteria ADD COLUMN IF NOT EXISTS weight DECIMAL(5,4) DEFAULT 0")
 	fmt.Println("Manual migration: Added weight column to criteria table")
 
+	// Manual migration untuk metode individual DM selain TOPSIS
+	db.Exec("ALTER TABLE project_decision_makers DROP CONSTRAINT IF EXISTS chk_project_decision_makers_method")
+	db.Exec("ALTER TABLE project_decision_makers ADD CONSTRAINT chk_project_decision_makers_method CHECK (method IN ('TOPSIS','SAW','WP','AHP'))")
+	fmt.Println("Manual migration: Allowed TOPSIS, SAW, WP and AHP as DM methods")
+
+	// Manual migration untuk mode pembobotan proyek
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS weighting_mode VARCHAR(50) DEFAULT 'ADMIN'")
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS weight_blend DECIMAL(5,4) DEFAULT 0.5")
+	db.Exec("ALTER TABLE decision_projects DROP CONSTRAINT IF EXISTS chk_decision_projects_weighting_mode")
+	db.Exec("ALTER TABLE decision_projects ADD CONSTRAINT chk_decision_projects_weighting_mode CHECK (weighting_mode IN ('ADMIN','DM_DIRECT','BLEND','ENTROPY','ENTROPY_POOLED','CRITIC','CRITIC_POOLED'))")
+	fmt.Println("Manual migration: Added weighting mode columns to decision_projects table")
+
+	// Manual migration untuk pilihan normalisasi TOPSIS
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS normalization VARCHAR(50) DEFAULT 'VECTOR'")
+	db.Exec("ALTER TABLE decision_projects DROP CONSTRAINT IF EXISTS chk_decision_projects_normalization")
+	db.Exec("ALTER TABLE decision_projects ADD CONSTRAINT chk_decision_projects_normalization CHECK (normalization IN ('VECTOR','LINEAR_MAX','MIN_MAX','LINEAR_SUM'))")
+	db.Exec("ALTER TABLE result_rankings ADD COLUMN IF NOT EXISTS normalization VARCHAR(50)")
+	fmt.Println("Manual migration: Added normalization columns to decision_projects and result_rankings tables")
+
+	// Manual migration untuk penanganan ranking seri
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS tie_policy VARCHAR(50) DEFAULT 'SHARED'")
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS tie_break_rule VARCHAR(50) DEFAULT 'ALTERNATIVE_ID'")
+	db.Exec("ALTER TABLE decision_projects DROP CONSTRAINT IF EXISTS chk_decision_projects_tie_policy")
+	db.Exec("ALTER TABLE decision_projects ADD CONSTRAINT chk_decision_projects_tie_policy CHECK (tie_policy IN ('SHARED','FRACTIONAL','TIEBREAK'))")
+	db.Exec("ALTER TABLE decision_projects DROP CONSTRAINT IF EXISTS chk_decision_projects_tie_break_rule")
+	db.Exec("ALTER TABLE decision_projects ADD CONSTRAINT chk_decision_projects_tie_break_rule CHECK (tie_break_rule IN ('ALTERNATIVE_ID','FIRST_PLACES'))")
+	db.Exec("ALTER TABLE result_rankings ADD COLUMN IF NOT EXISTS is_tied BOOLEAN DEFAULT FALSE")
+	fmt.Println("Manual migration: Added tie handling columns to decision_projects and result_rankings tables")
+
+	// Manual migration untuk metode agregasi kelompok
+	db.Exec("ALTER TABLE decision_projects DROP CONSTRAINT IF EXISTS chk_decision_projects_aggregation_method")
+	db.Exec("ALTER TABLE decision_projects ADD CONSTRAINT chk_decision_projects_aggregation_method CHECK (aggregation_method IN ('BORDA','COPELAND','KEMENY','SCHULZE','LAINNYA'))")
+	fmt.Println("Manual migration: Allowed BORDA, COPELAND, KEMENY and SCHULZE as aggregation methods")
+
+	// Manual migration untuk mode kelompok AIP/AIJ
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS group_mode VARCHAR(50) DEFAULT 'AIP'")
+	db.Exec("ALTER TABLE decision_projects DROP CONSTRAINT IF EXISTS chk_decision_projects_group_mode")
+	db.Exec("ALTER TABLE decision_projects ADD CONSTRAINT chk_decision_projects_group_mode CHECK (group_mode IN ('AIP','AIJ_ARITHMETIC','AIJ_GEOMETRIC'))")
+	fmt.Println("Manual migration: Added group mode column to decision_projects table")
+
+	// Manual migration untuk putaran konsensus (Delphi)
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS current_round INTEGER NOT NULL DEFAULT 1")
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS consensus_threshold DECIMAL(5,4) DEFAULT 0.7")
+	db.Exec("ALTER TABLE dm_inputs_scores ADD COLUMN IF NOT EXISTS round_number INTEGER NOT NULL DEFAULT 1")
+	db.Exec("DROP INDEX IF EXISTS idx_score_dm_alt_crit")
+	db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_score_dm_alt_crit ON dm_inputs_scores (project_dm_id, alternative_id, criteria_id, round_number)")
+	db.Exec("ALTER TABLE dm_inputs_direct_weights ADD COLUMN IF NOT EXISTS round_number INTEGER NOT NULL DEFAULT 1")
+	db.Exec("ALTER TABLE dm_inputs_pairwises ADD COLUMN IF NOT EXISTS round_number INTEGER NOT NULL DEFAULT 1")
+	db.Exec("ALTER TABLE result_rankings ADD COLUMN IF NOT EXISTS round_number INTEGER NOT NULL DEFAULT 1")
+	db.Exec(`CREATE TABLE IF NOT EXISTS project_rounds (
+		round_id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES decision_projects(project_id) ON UPDATE CASCADE ON DELETE CASCADE,
+		round_number INTEGER NOT NULL,
+		status VARCHAR(50) DEFAULT 'open' CONSTRAINT chk_project_rounds_status CHECK (status IN ('open','closed')),
+		kendall_w DECIMAL(10,6),
+		opened_at TIMESTAMPTZ,
+		closed_at TIMESTAMPTZ
+	)`)
+	db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_round_proj_number ON project_rounds (project_id, round_number)")
+	fmt.Println("Manual migration: Added consensus rounds to inputs, results and project_rounds table")
+
+	db.Exec(`CREATE TABLE IF NOT EXISTS calculation_traces (
+		trace_id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES decision_projects(project_id) ON UPDATE CASCADE ON DELETE CASCADE,
+		round_number INTEGER NOT NULL DEFAULT 1,
+		trace JSONB NOT NULL,
+		created_at TIMESTAMPTZ
+	)`)
+	fmt.Println("Manual migration: Added calculation_traces table")
+
+	db.Exec(`CREATE TABLE IF NOT EXISTS calculation_runs (
+		run_id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES decision_projects(project_id) ON UPDATE CASCADE ON DELETE CASCADE,
+		round_number INTEGER NOT NULL DEFAULT 1,
+		triggered_by_admin_id BIGINT NOT NULL,
+		aggregation_method VARCHAR(50),
+		group_mode VARCHAR(50),
+		weighting_mode VARCHAR(50),
+		normalization VARCHAR(50),
+		tie_policy VARCHAR(50),
+		tie_break_rule VARCHAR(50),
+		methods JSONB,
+		weights JSONB,
+		created_at TIMESTAMPTZ
+	)`)
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_run_proj_round ON calculation_runs (project_id, round_number)")
+	db.Exec("ALTER TABLE result_rankings ADD COLUMN IF NOT EXISTS run_id BIGINT REFERENCES calculation_runs(run_id) ON UPDATE CASCADE ON DELETE CASCADE")
+	db.Exec("ALTER TABLE calculation_traces ADD COLUMN IF NOT EXISTS run_id BIGINT REFERENCES calculation_runs(run_id) ON UPDATE CASCADE ON DELETE CASCADE")
+	// Hasil lama tanpa run: satu run per proyek dan putaran
+	db.Exec(`INSERT INTO calculation_runs (project_id, round_number, triggered_by_admin_id, aggregation_method, group_mode, weighting_mode, normalization, tie_policy, tie_break_rule, created_at)
+		SELECT DISTINCT r.project_id, r.round_number, p.created_by_admin_id, p.aggregation_method, p.group_mode, p.weighting_mode, p.normalization, p.tie_policy, p.tie_break_rule, NOW()
+		FROM result_rankings r JOIN decision_projects p ON p.project_id = r.project_id
+		WHERE r.run_id IS NULL`)
+	db.Exec(`UPDATE result_rankings r SET run_id = (
+		SELECT MAX(c.run_id) FROM calculation_runs c WHERE c.project_id = r.project_id AND c.round_number = r.round_number
+	) WHERE r.run_id IS NULL`)
+	db.Exec(`UPDATE calculation_traces t SET run_id = (
+		SELECT MAX(c.run_id) FROM calculation_runs c WHERE c.project_id = t.project_id AND c.round_number = t.round_number
+	) WHERE t.run_id IS NULL`)
+	db.Exec("DROP INDEX IF EXISTS idx_result_proj_alt_dm")
+	db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_result_proj_alt_dm ON result_rankings (project_id, alternative_id, project_dm_id, round_number, run_id)")
+	db.Exec("DROP INDEX IF EXISTS idx_trace_proj_round")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_trace_proj_round ON calculation_traces (project_id, round_number)")
+	db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_trace_run ON calculation_traces (run_id)")
+	fmt.Println("Manual migration: Added calculation_runs and keyed results and traces by run")
+
+	db.Exec("ALTER TABLE calculation_runs ADD COLUMN IF NOT EXISTS input_snapshot TEXT")
+	db.Exec("ALTER TABLE calculation_runs ADD COLUMN IF NOT EXISTS input_hash VARCHAR(64)")
+	db.Exec("ALTER TABLE calculation_runs ADD COLUMN IF NOT EXISTS result_hash VARCHAR(64)")
+	fmt.Println("Manual migration: Added input snapshot and hashes to calculation_runs")
+
+	// Manual migration untuk kebijakan skor yang kosong
+	db.Exec("ALTER TABLE decision_projects ADD COLUMN IF NOT EXISTS missing_score_policy VARCHAR(50) DEFAULT 'BLOCK'")
+	db.Exec("ALTER TABLE decision_projects DROP CONSTRAINT IF EXISTS chk_decision_projects_missing_score_policy")
+	db.Exec("ALTER TABLE decision_projects ADD CONSTRAINT chk_decision_projects_missing_score_policy CHECK (missing_score_policy IN ('BLOCK','IMPUTE_MEAN','IMPUTE_MEDIAN','EXCLUDE_DM'))")
+	db.Exec("ALTER TABLE calculation_runs ADD COLUMN IF NOT EXISTS missing_score_policy VARCHAR(50)")
+	fmt.Println("Manual migration: Added missing score policy to decision_projects and calculation_runs")
+
+	// Manual migration untuk skala skor per kriteria
+	db.Exec("ALTER TABLE criteria ADD COLUMN IF NOT EXISTS scale_type VARCHAR(20) DEFAULT 'NUMERIC'")
+	db.Exec("ALTER TABLE criteria DROP CONSTRAINT IF EXISTS chk_criteria_scale_type")
+	db.Exec("ALTER TABLE criteria ADD CONSTRAINT chk_criteria_scale_type CHECK (scale_type IN ('NUMERIC','LIKERT_5','ORDINAL'))")
+	db.Exec("ALTER TABLE criteria ADD COLUMN IF NOT EXISTS scale_min DECIMAL(10,4)")
+	db.Exec("ALTER TABLE criteria ADD COLUMN IF NOT EXISTS scale_max DECIMAL(10,4)")
+	db.Exec("ALTER TABLE criteria ADD COLUMN IF NOT EXISTS scale_step DECIMAL(10,4)")
+	db.Exec("ALTER TABLE criteria ADD COLUMN IF NOT EXISTS scale_labels TEXT")
+	fmt.Println("Manual migration: Added score scale columns to criteria table")
+
 	userReository := repository.CreateUserRepository(db)
 	projectRepository := repository.NewProjectRepository(db)
 	criteriarepository := repository.NewCriteriaRepository(db)
@@ -49,24 +180,43 @@ func main() {
 	project_dm_repository := repository.NewProjectDMRepository(db)
 	inputDirectWeightRepository := repository.NewInputDirectWeigtrepository(db)
 	inputScoreRepository := repository.NewInputScoreRepository(db)
+	inputPairwiseRepository := repository.NewInputPairwiseRepository(db)
 	resultRepository := repository.NewResultRankingRepository(db)
-
+	roundRepository := repository.NewProjectRoundRepository(db)
+	traceRepository := repository.NewCalculationTraceRepository(db)
+	runRepository := repository.NewCalculationRunRepository(db)
 
 	topsisCalc := calculations.NewTOPSISCalculator()
 	bordaCalc := calculations.NewBordaCalculator()
+	ahpCalc := calculations.NewAHPCalculator()
+	sawCalc := calculations.NewSAWCalculator()
+	wpCalc := calculations.NewWPCalculator()
+	copelandCalc := calculations.NewCopelandCalculator()
+	kemenyCalc := calculations.NewKemenyCalculator()
+	schulzeCalc := calculations.NewSchulzeCalculator()
+	entropyWeighting := calculations.NewEntropyWeighting()
+	criticWeighting := calculations.NewCRITICWeighting()
+	aggregatorRegistry := calculations.NewAggregatorRegistry(bordaCalc, copelandCalc, kemenyCalc, schulzeCalc)
+	methodRegistry := calculations.NewMethodRegistry(topsisCalc, sawCalc, wpCalc, ahpCalc)
+	weightingRegistry := calculations.NewWeightingRegistry(entropyWeighting, criticWeighting)
 
 	authService := service.NewAuthService(userReository)
 	userService := service.NewUserService(userReository)
-	projectService := service.NewProjectService(projectRepository)
+	projectService := service.NewProjectService(projectRepository, aggregatorRegistry)
 	criteriService := service.NewCriteriaService(criteriarepository, projectRepository)
 	alternativeService := service.NewAlternativeService(alternativeRepository, projectRepository)
-	projectDMService := service.NewProjectDMService(project_dm_repository, projectRepository, userReository)
-	inputDirectWeightService := service.NewInputDirectWeightService(inputDirectWeightRepository, project_dm_repository)
-	inputScoreService := service.NewInputScoreService(inputScoreRepository, project_dm_repository)
+	projectDMService := service.NewProjectDMService(project_dm_repository, projectRepository, userReository, methodRegistry)
+	inputDirectWeightService := service.NewInputDirectWeightService(inputDirectWeightRepository, project_dm_repository, criteriarepository, projectRepository)
+	inputScoreService := service.NewInputScoreService(inputScoreRepository, project_dm_repository, criteriarepository, projectRepository)
+	inputPairwiseService := service.NewInputPairwiseService(inputPairwiseRepository, project_dm_repository, criteriarepository, projectRepository, ahpCalc)
 	decisionService := service.NewDecisionService(
 		projectRepository, criteriarepository, alternativeRepository, project_dm_repository,
-		inputDirectWeightRepository, inputScoreRepository, resultRepository,
-		 topsisCalc, bordaCalc,
+		inputDirectWeightRepository, inputScoreRepository, resultRepository, inputPairwiseRepository, traceRepository, runRepository,
+		methodRegistry, ahpCalc, aggregatorRegistry, weightingRegistry,
+	)
+	roundService := service.NewRoundService(
+		roundRepository, projectRepository, project_dm_repository,
+		inputScoreRepository, inputDirectWeightRepository, resultRepository,
 	)
 
 	authHandler := handler.NewAuthHandler(authService)
@@ -77,7 +227,9 @@ func main() {
 	projectDMHandler := handler.NewProjectDMHandler(projectDMService)
 	inputDirectWeightHandler := handler.NewInputDirectWeightHandler(inputDirectWeightService)
 	inputScoreHandler := handler.NewInputScoreHandler(inputScoreService)
+	inputPairwiseHandler := handler.NewInputPairwiseHandler(inputPairwiseService)
 	decisionHandler := handler.NewDecisionHandler(decisionService)
+	roundHandler := handler.NewRoundHandler(roundService)
 
 	r := gin.Default()
 
@@ -104,7 +256,9 @@ func main() {
 	routes.SetupProjectDMRoutes(r, projectDMHandler)
 	routes.SetupInputDirectWeightRoutes(r, inputDirectWeightHandler)
 	routes.SetupInputScoreRoutes(r, inputScoreHandler)
+	routes.SetupInputPairwiseRoutes(r, inputPairwiseHandler)
 	routes.SetupDecisionRoutes(r, decisionHandler)
+	routes.SetupRoundRoutes(r, roundHandler)
 
 	log.Println("Starting server on port 8084....")
 	r.Run("0.0.0.0:8084")
@@ -0,0 +1,140 @@
+package calculations
+
+import (
+	"sort"
+	"sync"
+)
+
+const (
+	AggregationBorda    = "BORDA"
+	AggregationCopeland = "COPELAND"
+	AggregationKemeny   = "KEMENY"
+	AggregationSchulze  = "SCHULZE"
+)
+
+// AggregationResult is the group ranking produced by an aggregator
+type AggregationResult struct {
+	Method string            `json:"method"`
+	Ranks  []AlternativeRank `json:"ranks"`
+
+	// PairwiseMatrix[i][j] is the total DM weight preferring alternative i over j
+	PairwiseMatrix map[uint]map[uint]float64 `json:"pairwise_matrix,omitempty"`
+	// MajorityMatrix[i][j] is 1 if i beats j by majority, -1 if it loses and 0 on a tie
+	MajorityMatrix map[uint]map[uint]int `json:"majority_matrix,omitempty"`
+
+	// BordaPoints[dm][alt] is the weighted points a DM gave an alternative; BordaTotals sums them per alternative
+	BordaPoints map[uint]map[uint]float64 `json:"borda_points,omitempty"`
+	BordaTotals map[uint]float64          `json:"borda_totals,omitempty"`
+
+	// KemenyDistance is the total weighted Kendall-tau distance of the consensus to the DM rankings
+	KemenyDistance *float64 `json:"kemeny_distance,omitempty"`
+	// Exact is false when the consensus comes from the local search heuristic
+	Exact *bool `json:"exact,omitempty"`
+
+	// StrongestPaths[i][j] is the strength of the strongest Schulze beatpath from i to j
+	StrongestPaths map[uint]map[uint]float64 `json:"strongest_paths,omitempty"`
+	// HasCondorcetWinner reports whether an alternative beats every other one head-to-head
+	HasCondorcetWinner *bool `json:"has_condorcet_winner,omitempty"`
+	CondorcetWinner    *uint `json:"condorcet_winner,omitempty"`
+}
+
+// GroupAggregator combines the per-DM rankings into a single group ranking
+type GroupAggregator interface {
+	Name() string
+	Aggregate(dmRankings []SingleDMRanking, ties TieOptions) (*AggregationResult, error)
+}
+
+type AggregatorRegistry interface {
+	Register(aggregator GroupAggregator)
+	Get(name string) (GroupAggregator, bool)
+	Names() []string
+}
+
+type aggregatorRegistry struct {
+	mu          sync.RWMutex
+	aggregators map[string]GroupAggregator
+}
+
+func NewAggregatorRegistry(aggregators ...GroupAggregator) AggregatorRegistry {
+	registry := &aggregatorRegistry{aggregators: make(map[string]GroupAggregator)}
+	for _, a := range aggregators {
+		registry.Register(a)
+	}
+	return registry
+}
+
+func (r *aggregatorRegistry) Register(aggregator GroupAggregator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aggregators[aggregator.Name()] = aggregator
+}
+
+func (r *aggregatorRegistry) Get(name string) (GroupAggregator, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	aggregator, ok := r.aggregators[name]
+	return aggregator, ok
+}
+
+func (r *aggregatorRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.aggregators))
+	for name := range r.aggregators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// dmWeightOrDefault mirrors Borda: a DM without a group weight counts as 1
+func dmWeightOrDefault(weight float64) float64 {
+	if weight == 0 {
+		return 1.0
+	}
+	return weight
+}
+
+// alternativeIDs returns the alternatives ranked by the first DM, sorted by ID
+func alternativeIDs(dmRankings []SingleDMRanking) []uint {
+	if len(dmRankings) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(dmRankings[0].RankedList))
+	for _, r := range dmRankings[0].RankedList {
+		ids = append(ids, r.AlternativeID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// buildPairwisePreferences counts, for every pair (i, j), the weighted DMs ranking i strictly above j
+func buildPairwisePreferences(dmRankings []SingleDMRanking, ids []uint) map[uint]map[uint]float64 {
+	prefs := make(map[uint]map[uint]float64)
+	for _, i := range ids {
+		prefs[i] = make(map[uint]float64)
+		for _, j := range ids {
+			if i != j {
+				prefs[i][j] = 0
+			}
+		}
+	}
+
+	for _, dm := range dmRankings {
+		weight := dmWeightOrDefault(dm.DMWeight)
+		rankOf := make(map[uint]int)
+		for _, r := range dm.RankedList {
+			rankOf[r.AlternativeID] = r.Rank
+		}
+		for _, i := range ids {
+			for _, j := range ids {
+				ri, okI := rankOf[i]
+				rj, okJ := rankOf[j]
+				if i != j && okI && okJ && ri < rj {
+					prefs[i][j] += weight
+				}
+			}
+		}
+	}
+	return prefs
+}
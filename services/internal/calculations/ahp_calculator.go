@@ -0,0 +1,251 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"services/internal/models"
+)
+
+const (
+	AHPMethodEigenvector    = "EIGENVECTOR"
+	AHPMethodGeometricMean  = "GEOMETRIC_MEAN"
+	AHPConsistencyThreshold = 0.1
+)
+
+// Random Index (RI) Saaty untuk ukuran matriks n = 0..15
+var saatyRandomIndex = []float64{0, 0, 0, 0.58, 0.90, 1.12, 1.24, 1.32, 1.41, 1.45, 1.49, 1.51, 1.48, 1.56, 1.57, 1.59}
+
+type AHPResult struct {
+	Priorities map[uint]float64 `json:"priorities"`
+	LambdaMax  float64          `json:"lambda_max"`
+	CI         float64          `json:"ci"`
+	RI         float64          `json:"ri"`
+	CR         float64          `json:"cr"`
+	Consistent bool             `json:"consistent"`
+}
+
+type AHPCalculator interface {
+	DecisionMethod
+	CalculatePriorities(criteriaIDs []uint, comparisons []models.DMInputPairwise, method string) (*AHPResult, error)
+}
+
+type ahpCalculator struct{}
+
+func NewAHPCalculator() AHPCalculator {
+	return &ahpCalculator{}
+}
+
+func (calc *ahpCalculator) CalculatePriorities(criteriaIDs []uint, comparisons []models.DMInputPairwise, method string) (*AHPResult, error) {
+	n := len(criteriaIDs)
+	if n == 0 {
+		return nil, errors.New("AHP: tidak ada kriteria untuk dibandingkan")
+	}
+	if method == "" {
+		method = AHPMethodEigenvector
+	}
+	if method != AHPMethodEigenvector && method != AHPMethodGeometricMean {
+		return nil, fmt.Errorf("AHP: metode prioritas tidak dikenal: %s", method)
+	}
+
+	index := make(map[uint]int)
+	for i, id := range criteriaIDs {
+		index[id] = i
+	}
+
+	// 1. Build reciprocal matrix A (diagonal = 1, a_ji = 1 / a_ij)
+	A := make([][]float64, n)
+	for i := range A {
+		A[i] = make([]float64, n)
+		A[i][i] = 1
+	}
+	for _, cmp := range comparisons {
+		i, ok1 := index[cmp.Criteria1ID]
+		j, ok2 := index[cmp.Criteria2ID]
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("AHP: perbandingan %d-%d tidak termasuk dalam kelompok kriteria", cmp.Criteria1ID, cmp.Criteria2ID)
+		}
+		if i == j {
+			return nil, errors.New("AHP: kriteria tidak boleh dibandingkan dengan dirinya sendiri")
+		}
+		if cmp.Value <= 0 {
+			return nil, errors.New("AHP: nilai perbandingan harus > 0")
+		}
+		A[i][j] = cmp.Value
+		A[j][i] = 1 / cmp.Value
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if A[i][j] == 0 {
+				return nil, fmt.Errorf("AHP: perbandingan kriteria %d dan %d belum diisi", criteriaIDs[i], criteriaIDs[j])
+			}
+		}
+	}
+
+	// 2. Priority vector
+	var w []float64
+	if method == AHPMethodGeometricMean {
+		w = geometricMeanPriorities(A)
+	} else {
+		w = eigenvectorPriorities(A)
+	}
+
+	// 3. Consistency: lambda_max, CI, CR
+	lambdaMax := 0.0
+	for i := 0; i < n; i++ {
+		rowSum := 0.0
+		for j := 0; j < n; j++ {
+			rowSum += A[i][j] * w[j]
+		}
+		if w[i] > 0 {
+			lambdaMax += rowSum / w[i]
+		}
+	}
+	lambdaMax /= float64(n)
+
+	result := &AHPResult{
+		Priorities: make(map[uint]float64),
+		LambdaMax:  lambdaMax,
+	}
+	for i, id := range criteriaIDs {
+		result.Priorities[id] = w[i]
+	}
+
+	if n > 2 {
+		result.CI = (lambdaMax - float64(n)) / float64(n-1)
+		result.RI = saatyRandomIndex[len(saatyRandomIndex)-1]
+		if n < len(saatyRandomIndex) {
+			result.RI = saatyRandomIndex[n]
+		}
+		result.CR = result.CI / result.RI
+	}
+	result.Consistent = result.CR <= AHPConsistencyThreshold
+
+	return result, nil
+}
+
+func (calc *ahpCalculator) Name() string {
+	return MethodAHP
+}
+
+// CalculateRanking menjalankan AHP mode rating: bobot kriteria berasal dari matriks
+// perbandingan berpasangan DM, sedangkan prioritas lokal alternatif diturunkan dari
+// skor yang dinormalisasi secara distributif (cost memakai kebalikan skor).
+func (calc *ahpCalculator) CalculateRanking(
+	scores []models.DMInputScore,
+	criteria []models.Criteria,
+	alternatives []models.Alternative,
+	weights map[uint]float64,
+	options RankingOptions,
+) ([]TOPSISRank, error) {
+	if len(criteria) == 0 || len(alternatives) == 0 || len(scores) == 0 {
+		return nil, errors.New("AHP: data tidak lengkap")
+	}
+
+	scoreMatrix := make(map[uint]map[uint]float64)
+	for _, a := range alternatives {
+		scoreMatrix[a.AlternativeID] = make(map[uint]float64)
+	}
+	for _, s := range scores {
+		if _, ok := scoreMatrix[s.AlternativeID]; ok {
+			scoreMatrix[s.AlternativeID][s.CriteriaID] = s.ScoreValue
+		}
+	}
+
+	// Prioritas lokal tiap alternatif per kriteria
+	local := make(map[uint]map[uint]float64)
+	for _, a := range alternatives {
+		local[a.AlternativeID] = make(map[uint]float64)
+	}
+	for _, c := range criteria {
+		sum := 0.0
+		values := make(map[uint]float64)
+		for _, a := range alternatives {
+			x := scoreMatrix[a.AlternativeID][c.CriteriaID]
+			if c.Type == "cost" {
+				if x <= 0 {
+					return nil, fmt.Errorf("AHP: skor kriteria cost %s harus > 0", c.Name)
+				}
+				x = 1 / x
+			}
+			values[a.AlternativeID] = x
+			sum += x
+		}
+		for _, a := range alternatives {
+			if sum != 0 {
+				local[a.AlternativeID][c.CriteriaID] = values[a.AlternativeID] / sum
+			}
+		}
+	}
+
+	var results []TOPSISRank
+	for _, a := range alternatives {
+		total := 0.0
+		for _, c := range criteria {
+			total += weights[c.CriteriaID] * local[a.AlternativeID][c.CriteriaID]
+		}
+		results = append(results, TOPSISRank{
+			AlternativeID: a.AlternativeID,
+			FinalScore:    total,
+		})
+	}
+
+	// Sort by FinalScore descending with tie-aware ranks
+	results, err := rankMethodResults(results, options.Ties)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// eigenvectorPriorities menghitung principal eigenvector dengan power iteration.
+func eigenvectorPriorities(A [][]float64) []float64 {
+	n := len(A)
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 1 / float64(n)
+	}
+
+	for iter := 0; iter < 1000; iter++ {
+		next := make([]float64, n)
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				next[i] += A[i][j] * w[j]
+			}
+			sum += next[i]
+		}
+
+		diff := 0.0
+		for i := range next {
+			next[i] /= sum
+			diff = math.Max(diff, math.Abs(next[i]-w[i]))
+		}
+		w = next
+		if diff < 1e-12 {
+			break
+		}
+	}
+	return w
+}
+
+// geometricMeanPriorities menghitung prioritas dari rata-rata geometrik tiap baris.
+func geometricMeanPriorities(A [][]float64) []float64 {
+	n := len(A)
+	w := make([]float64, n)
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		logSum := 0.0
+		for j := 0; j < n; j++ {
+			logSum += math.Log(A[i][j])
+		}
+		w[i] = math.Exp(logSum / float64(n))
+		sum += w[i]
+	}
+	for i := range w {
+		w[i] /= sum
+	}
+	return w
+}
@@ -0,0 +1,123 @@
+package calculations
+
+import (
+	"math"
+	"reflect"
+	"services/internal/models"
+	"testing"
+)
+
+// pairwiseMatrix turns the upper triangle of a 3×3 judgement matrix into comparisons of criteria 1, 2 and 3
+func pairwiseMatrix(a12, a13, a23 float64) []models.DMInputPairwise {
+	return []models.DMInputPairwise{
+		{Criteria1ID: 1, Criteria2ID: 2, Value: a12},
+		{Criteria1ID: 1, Criteria2ID: 3, Value: a13},
+		{Criteria1ID: 2, Criteria2ID: 3, Value: a23},
+	}
+}
+
+func TestAHPCalculatePriorities(t *testing.T) {
+	const tolerance = 1e-3
+
+	tests := []struct {
+		name        string
+		comparisons []models.DMInputPairwise
+		priorities  []float64
+		lambdaMax   float64
+		cr          float64
+		consistent  bool
+	}{
+		{
+			// a_ij = w_i / w_j untuk w = (0.5, 0.3, 0.2): konsisten sempurna
+			name:        "perfectly consistent",
+			comparisons: pairwiseMatrix(0.5/0.3, 0.5/0.2, 0.3/0.2),
+			priorities:  []float64{0.5, 0.3, 0.2},
+			lambdaMax:   3,
+			cr:          0,
+			consistent:  true,
+		},
+		{
+			// Contoh Saaty yang sering dikutip: w ≈ (0.637, 0.258, 0.105), λmax ≈ 3.0385, CR ≈ 0.033
+			name:        "Saaty reference, acceptable",
+			comparisons: pairwiseMatrix(3, 5, 3),
+			priorities:  []float64{0.637, 0.258, 0.105},
+			lambdaMax:   3.0385,
+			cr:          0.0332,
+			consistent:  true,
+		},
+		{
+			// Penilaian siklis (1 > 2 > 3 > 1): w = 1/3, λmax = 1 + 9 + 1/9, CR = (λmax - 3) / 2 / 0.58
+			name:        "cyclic, inconsistent",
+			comparisons: pairwiseMatrix(9, 1.0/9, 9),
+			priorities:  []float64{1.0 / 3, 1.0 / 3, 1.0 / 3},
+			lambdaMax:   1 + 9 + 1.0/9,
+			cr:          (1 + 9 + 1.0/9 - 3) / 2 / 0.58,
+			consistent:  false,
+		},
+	}
+
+	calc := NewAHPCalculator()
+	for _, tt := range tests {
+		for _, method := range []string{AHPMethodEigenvector, AHPMethodGeometricMean} {
+			t.Run(tt.name+"/"+method, func(t *testing.T) {
+				result, err := calc.CalculatePriorities([]uint{1, 2, 3}, tt.comparisons, method)
+				if err != nil {
+					t.Fatalf("CalculatePriorities: %v", err)
+				}
+				for i, want := range tt.priorities {
+					if got := result.Priorities[uint(i+1)]; math.Abs(got-want) > tolerance {
+						t.Errorf("priority of criteria %d = %.4f, want %.4f", i+1, got, want)
+					}
+				}
+				if math.Abs(result.LambdaMax-tt.lambdaMax) > tolerance {
+					t.Errorf("lambda_max = %.4f, want %.4f", result.LambdaMax, tt.lambdaMax)
+				}
+				if math.Abs(result.CR-tt.cr) > tolerance {
+					t.Errorf("CR = %.4f, want %.4f", result.CR, tt.cr)
+				}
+				if result.RI != 0.58 {
+					t.Errorf("RI = %.2f, want 0.58", result.RI)
+				}
+				if result.Consistent != tt.consistent {
+					t.Errorf("consistent = %v, want %v", result.Consistent, tt.consistent)
+				}
+			})
+		}
+	}
+}
+
+func TestAHPCalculatePrioritiesRejectsIncompleteMatrix(t *testing.T) {
+	comparisons := []models.DMInputPairwise{
+		{Criteria1ID: 1, Criteria2ID: 2, Value: 3},
+		{Criteria1ID: 1, Criteria2ID: 3, Value: 5},
+	}
+	if _, err := NewAHPCalculator().CalculatePriorities([]uint{1, 2, 3}, comparisons, AHPMethodEigenvector); err == nil {
+		t.Fatal("expected an error for a missing comparison")
+	}
+}
+
+func TestAHPCalculatePrioritiesMethodDispatch(t *testing.T) {
+	calc := NewAHPCalculator()
+	comparisons := pairwiseMatrix(3, 5, 3)
+	ids := []uint{1, 2, 3}
+
+	eigenvector, err := calc.CalculatePriorities(ids, comparisons, AHPMethodEigenvector)
+	if err != nil {
+		t.Fatalf("CalculatePriorities: %v", err)
+	}
+	// Tanpa metode dipakai EIGENVECTOR
+	byDefault, err := calc.CalculatePriorities(ids, comparisons, "")
+	if err != nil {
+		t.Fatalf("CalculatePriorities without method: %v", err)
+	}
+	if !reflect.DeepEqual(byDefault, eigenvector) {
+		t.Errorf("default method gave %+v, want the eigenvector result %+v", byDefault, eigenvector)
+	}
+
+	if _, err := calc.CalculatePriorities(ids, comparisons, "LLSM"); err == nil {
+		t.Error("expected an error for an unknown priority method")
+	}
+	if _, err := calc.CalculatePriorities(nil, nil, AHPMethodEigenvector); err == nil {
+		t.Error("expected an error without criteria")
+	}
+}
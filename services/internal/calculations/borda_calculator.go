@@ -1,14 +1,15 @@
 package calculations
 
 import (
-	"log"
+	"errors"
 	"sort"
 )
 
 type AlternativeRank struct {
-	AlternativeID uint
-	Rank          int
-	Score         float64
+	AlternativeID uint    `json:"alternative_id"`
+	Rank          int     `json:"rank"`
+	Score         float64 `json:"score"`
+	Tied          bool    `json:"tied,omitempty"`
 }
 
 type SingleDMRanking struct {
@@ -18,7 +19,8 @@ type SingleDMRanking struct {
 }
 
 type BordaCalculator interface {
-	AggregateBorda(dmRankings []SingleDMRanking) []AlternativeRank
+	GroupAggregator
+	AggregateBorda(dmRankings []SingleDMRanking, ties TieOptions) ([]AlternativeRank, error)
 }
 
 type bordaCalculator struct{}
@@ -27,14 +29,43 @@ func NewBordaCalculator() BordaCalculator {
 	return &bordaCalculator{}
 }
 
-func (bc *bordaCalculator) AggregateBorda(dmRankings []SingleDMRanking) []AlternativeRank {
+func (bc *bordaCalculator) Name() string {
+	return AggregationBorda
+}
+
+func (bc *bordaCalculator) Aggregate(dmRankings []SingleDMRanking, ties TieOptions) (*AggregationResult, error) {
+	ranks, dmPoints, rawPoints, err := aggregateBorda(dmRankings, ties)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranks) == 0 {
+		return nil, errors.New("gagal menghitung ranking Borda")
+	}
+	return &AggregationResult{
+		Method:      AggregationBorda,
+		Ranks:       ranks,
+		BordaPoints: dmPoints,
+		BordaTotals: rawPoints,
+	}, nil
+}
+
+func (bc *bordaCalculator) AggregateBorda(dmRankings []SingleDMRanking, ties TieOptions) ([]AlternativeRank, error) {
+	ranks, _, _, err := aggregateBorda(dmRankings, ties)
+	return ranks, err
+}
+
+// aggregateBorda also returns the points every DM gave every alternative and the raw point totals
+func aggregateBorda(dmRankings []SingleDMRanking, ties TieOptions) ([]AlternativeRank, map[uint]map[uint]float64, map[uint]float64, error) {
 	if len(dmRankings) == 0 {
-		return []AlternativeRank{}
+		return []AlternativeRank{}, nil, nil, nil
+	}
+	if err := ties.validate(); err != nil {
+		return nil, nil, nil, err
 	}
 
 	// Count number of alternatives (assuming all DMs rank the same number of alternatives)
 	numAlternatives := len(dmRankings[0].RankedList)
-	
+
 	// Borda weights: rank 1 = 5, rank 2 = 4, rank 3 = 3, rank 4 = 2, rank 5 = 1
 	// Adjust based on number of alternatives
 	bordaWeights := make(map[int]float64)
@@ -42,12 +73,9 @@ func (bc *bordaCalculator) AggregateBorda(dmRankings []SingleDMRanking) []Altern
 		bordaWeights[i] = float64(numAlternatives - i + 1)
 	}
 
-	log.Printf("[Borda] Jumlah alternatif: %d", numAlternatives)
-	log.Printf("[Borda] Bobot: %v", bordaWeights)
-
 	// Create map to accumulate points for each alternative
 	bordaPoints := make(map[uint]float64)
-	
+
 	// Initialize all alternatives with 0 points
 	for _, dmRank := range dmRankings {
 		for _, altRank := range dmRank.RankedList {
@@ -58,67 +86,84 @@ func (bc *bordaCalculator) AggregateBorda(dmRankings []SingleDMRanking) []Altern
 
 	// Calculate Borda points according to Excel logic
 	// For each DM, for each alternative, add: BordaWeight(rank) * DMWeight
+	dmPoints := make(map[uint]map[uint]float64)
 	for _, dmRank := range dmRankings {
+		dmPoints[dmRank.DMID] = make(map[uint]float64)
 		dmWeight := dmRank.DMWeight
 		if dmWeight == 0 {
 			dmWeight = 1.0 // Default weight if not specified
 		}
-		
+
+		// Alternatives sharing a rank occupy the positions rank .. rank+size-1
+		groupSize := make(map[int]int)
+		for _, altRank := range dmRank.RankedList {
+			groupSize[altRank.Rank]++
+		}
+
 		for _, altRank := range dmRank.RankedList {
-			weight, exists := bordaWeights[altRank.Rank]
-			if !exists {
-				// If rank is out of range, use minimum weight
-				weight = 1.0
+			weight := bordaWeight(bordaWeights, altRank.Rank)
+			if ties.Policy == TiePolicyFractional && groupSize[altRank.Rank] > 1 {
+				// FRACTIONAL: tied alternatives split the points of the positions they occupy
+				size := groupSize[altRank.Rank]
+				weight = 0
+				for pos := altRank.Rank; pos < altRank.Rank+size; pos++ {
+					weight += bordaWeight(bordaWeights, pos)
+				}
+				weight /= float64(size)
 			}
-			
+
 			points := weight * dmWeight
 			bordaPoints[altRank.AlternativeID] += points
-			
-			log.Printf("[Borda] DM %d - Alt %d: Rank %d × Bobot %0.f × DMWeight %.1f = %.2f", 
-				dmRank.DMID, altRank.AlternativeID, altRank.Rank, weight, dmWeight, points)
+			dmPoints[dmRank.DMID][altRank.AlternativeID] = points
 		}
 	}
 
-	// Calculate total points for normalization
+	// Calculate total points for normalization, summed in ID order so every run gives the same bits
+	ids := make([]uint, 0, len(bordaPoints))
+	for altID := range bordaPoints {
+		ids = append(ids, altID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
 	totalPoints := 0.0
-	for _, points := range bordaPoints {
-		totalPoints += points
+	for _, altID := range ids {
+		totalPoints += bordaPoints[altID]
 	}
-	
-	log.Printf("[Borda] Total semua poin: %.2f", totalPoints)
 
-	// Normalize and create results
-	var results []AlternativeRank
-	for altID, rawPoints := range bordaPoints {
+	// Normalize scores
+	normalized := make(map[uint]float64)
+	for _, altID := range ids {
+		rawPoints := bordaPoints[altID]
 		normalizedScore := rawPoints
 		if totalPoints > 0 {
 			normalizedScore = rawPoints / totalPoints
 		}
-		
+		normalized[altID] = normalizedScore
+	}
+
+	// Sort by Score Descending and assign tie-aware ranks
+	compare := func(a, b uint) int { return compareScores(normalized[a], normalized[b]) }
+	order, ranks, tied, err := rankOrder(ids, compare, ties, firstPlaceVotes(dmRankings))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var results []AlternativeRank
+	for _, altID := range order {
 		results = append(results, AlternativeRank{
 			AlternativeID: altID,
-			Score:         normalizedScore,
+			Rank:          ranks[altID],
+			Score:         normalized[altID],
+			Tied:          tied[altID],
 		})
-		
-		log.Printf("[Borda] Alt %d: Raw=%.2f, Normalized=%.4f", 
-			altID, rawPoints, normalizedScore)
 	}
 
-	// Sort by Score Descending
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
-
-	// Assign ranks
-	for i := range results {
-		results[i].Rank = i + 1
-	}
+	return results, dmPoints, bordaPoints, nil
+}
 
-	// Log final ranking
-	log.Println("=== FINAL BORDA RANKING ===")
-	for _, r := range results {
-		log.Printf("Rank %d: Alternative ID %d (Score: %.4f)", r.Rank, r.AlternativeID, r.Score)
+// bordaWeight returns the points of a rank position; ranks out of range get the minimum weight
+func bordaWeight(bordaWeights map[int]float64, rank int) float64 {
+	if weight, ok := bordaWeights[rank]; ok {
+		return weight
 	}
-
-	return results
+	return 1.0
 }
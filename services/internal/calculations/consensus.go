@@ -0,0 +1,169 @@
+package calculations
+
+import (
+	"math"
+	"sort"
+)
+
+// RankVector maps an alternative to its rank (1 = best); shared ranks are allowed
+type RankVector map[uint]int
+
+// commonIDs returns the alternatives ranked in both vectors, sorted by ID
+func commonIDs(a, b RankVector) []uint {
+	var ids []uint
+	for id := range a {
+		if _, ok := b[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// midRanks turns competition ranks (1, 1, 3) into average ranks (1.5, 1.5, 3) over the given alternatives
+func midRanks(ranks RankVector, ids []uint) map[uint]float64 {
+	order := append([]uint(nil), ids...)
+	sort.SliceStable(order, func(i, j int) bool { return ranks[order[i]] < ranks[order[j]] })
+
+	mid := make(map[uint]float64)
+	for start := 0; start < len(order); {
+		end := start
+		for end < len(order) && ranks[order[end]] == ranks[order[start]] {
+			end++
+		}
+		// posisi start+1 .. end dirata-rata untuk kelompok seri
+		avg := float64(start+1+end) / 2
+		for k := start; k < end; k++ {
+			mid[order[k]] = avg
+		}
+		start = end
+	}
+	return mid
+}
+
+// SpearmanRho is the Pearson correlation of the (mid)ranks of two rankings
+func SpearmanRho(a, b RankVector) float64 {
+	ids := commonIDs(a, b)
+	n := float64(len(ids))
+	if n < 2 {
+		return 0
+	}
+	ra, rb := midRanks(a, ids), midRanks(b, ids)
+
+	meanA, meanB := 0.0, 0.0
+	for _, id := range ids {
+		meanA += ra[id]
+		meanB += rb[id]
+	}
+	meanA /= n
+	meanB /= n
+
+	cov, varA, varB := 0.0, 0.0, 0.0
+	for _, id := range ids {
+		da, db := ra[id]-meanA, rb[id]-meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+	if varA == 0 || varB == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varA*varB)
+}
+
+// KendallTauB compares every pair of alternatives and corrects for ties in either ranking
+func KendallTauB(a, b RankVector) float64 {
+	ids := commonIDs(a, b)
+	concordant, discordant, tiesA, tiesB := 0.0, 0.0, 0.0, 0.0
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			da := a[ids[i]] - a[ids[j]]
+			db := b[ids[i]] - b[ids[j]]
+			switch {
+			case da == 0 && db == 0:
+			case da == 0:
+				tiesA++
+			case db == 0:
+				tiesB++
+			case (da > 0) == (db > 0):
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+	denominator := math.Sqrt((concordant + discordant + tiesA) * (concordant + discordant + tiesB))
+	if denominator == 0 {
+		return 0
+	}
+	return (concordant - discordant) / denominator
+}
+
+// KendallDistance counts the pairs of alternatives the two rankings order in opposite directions
+func KendallDistance(a, b RankVector) int {
+	ids := commonIDs(a, b)
+	distance := 0
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			da := a[ids[i]] - a[ids[j]]
+			db := b[ids[i]] - b[ids[j]]
+			if (da > 0 && db < 0) || (da < 0 && db > 0) {
+				distance++
+			}
+		}
+	}
+	return distance
+}
+
+// KendallW is Kendall's coefficient of concordance of m rankings over the same n alternatives,
+// with the usual correction for tied ranks. 1 means full agreement, 0 none.
+func KendallW(rankings []RankVector) float64 {
+	m := float64(len(rankings))
+	if m < 2 {
+		return 0
+	}
+	var ids []uint
+	for id := range rankings[0] {
+		inAll := true
+		for _, r := range rankings[1:] {
+			if _, ok := r[id]; !ok {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	n := float64(len(ids))
+	if n < 2 {
+		return 0
+	}
+
+	sums := make(map[uint]float64)
+	tieCorrection := 0.0
+	for _, r := range rankings {
+		mid := midRanks(r, ids)
+		groups := make(map[int]float64)
+		for _, id := range ids {
+			sums[id] += mid[id]
+			groups[r[id]]++
+		}
+		for _, t := range groups {
+			tieCorrection += t*t*t - t
+		}
+	}
+
+	mean := m * (n + 1) / 2
+	s := 0.0
+	for _, id := range ids {
+		s += math.Pow(sums[id]-mean, 2)
+	}
+
+	denominator := m*m*(n*n*n-n) - m*tieCorrection
+	if denominator == 0 {
+		return 0
+	}
+	return 12 * s / denominator
+}
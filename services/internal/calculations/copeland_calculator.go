@@ -0,0 +1,82 @@
+package calculations
+
+import (
+	"errors"
+)
+
+type CopelandCalculator interface {
+	GroupAggregator
+}
+
+type copelandCalculator struct{}
+
+func NewCopelandCalculator() CopelandCalculator {
+	return &copelandCalculator{}
+}
+
+func (cc *copelandCalculator) Name() string {
+	return AggregationCopeland
+}
+
+func (cc *copelandCalculator) Aggregate(dmRankings []SingleDMRanking, ties TieOptions) (*AggregationResult, error) {
+	ids := alternativeIDs(dmRankings)
+	if len(ids) == 0 {
+		return nil, errors.New("gagal menghitung ranking Copeland")
+	}
+
+	// 1. Weighted pairwise win counts from every DM's ranked list
+	prefs := buildPairwisePreferences(dmRankings, ids)
+
+	// 2. Majority matrix and Copeland score (wins - losses)
+	majority := make(map[uint]map[uint]int)
+	copelandScore := make(map[uint]float64)
+	support := make(map[uint]float64)
+	for _, i := range ids {
+		majority[i] = make(map[uint]int)
+		for _, j := range ids {
+			if i == j {
+				continue
+			}
+			support[i] += prefs[i][j]
+			switch {
+			case prefs[i][j] > prefs[j][i]:
+				majority[i][j] = 1
+				copelandScore[i]++
+			case prefs[i][j] < prefs[j][i]:
+				majority[i][j] = -1
+				copelandScore[i]--
+			default:
+				majority[i][j] = 0
+			}
+		}
+	}
+
+	// Sort by Copeland score, then by total pairwise support
+	compare := func(a, b uint) int {
+		if c := compareScores(copelandScore[a], copelandScore[b]); c != 0 {
+			return c
+		}
+		return compareScores(support[a], support[b])
+	}
+	order, ranks, tied, err := rankOrder(ids, compare, ties, firstPlaceVotes(dmRankings))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AlternativeRank
+	for _, id := range order {
+		results = append(results, AlternativeRank{
+			AlternativeID: id,
+			Rank:          ranks[id],
+			Score:         copelandScore[id],
+			Tied:          tied[id],
+		})
+	}
+
+	return &AggregationResult{
+		Method:         AggregationCopeland,
+		Ranks:          results,
+		PairwiseMatrix: prefs,
+		MajorityMatrix: majority,
+	}, nil
+}
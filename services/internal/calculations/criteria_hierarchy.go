@@ -0,0 +1,84 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+	"services/internal/models"
+)
+
+// LeafCriteria returns the criteria without sub-criteria; only these are scored and ranked on
+func LeafCriteria(criteria []models.Criteria) []models.Criteria {
+	hasChildren := make(map[uint]bool)
+	for _, c := range criteria {
+		if c.ParentCriteriaID != nil {
+			hasChildren[*c.ParentCriteriaID] = true
+		}
+	}
+
+	var leaves []models.Criteria
+	for _, c := range criteria {
+		if !hasChildren[c.CriteriaID] {
+			leaves = append(leaves, c)
+		}
+	}
+	return leaves
+}
+
+// PropagateWeights turns local weights into global weights: global = local × global(parent)
+func PropagateWeights(criteria []models.Criteria, local map[uint]float64) map[uint]float64 {
+	criteriaMap := make(map[uint]models.Criteria)
+	for _, c := range criteria {
+		criteriaMap[c.CriteriaID] = c
+	}
+
+	global := make(map[uint]float64)
+	var globalWeight func(c models.Criteria, depth int) float64
+	globalWeight = func(c models.Criteria, depth int) float64 {
+		if w, ok := global[c.CriteriaID]; ok {
+			return w
+		}
+		w := local[c.CriteriaID]
+		// depth guard melindungi dari data parent yang melingkar
+		if c.ParentCriteriaID != nil && depth < len(criteria) {
+			if parent, ok := criteriaMap[*c.ParentCriteriaID]; ok {
+				w *= globalWeight(parent, depth+1)
+			}
+		}
+		global[c.CriteriaID] = w
+		return w
+	}
+
+	for _, c := range criteria {
+		globalWeight(c, 0)
+	}
+	return global
+}
+
+// ValidateSiblingWeights checks that the local weights of every group of sibling criteria sum to 1
+func ValidateSiblingWeights(criteria []models.Criteria, local map[uint]float64, tolerance float64) error {
+	criteriaMap := make(map[uint]models.Criteria)
+	sums := make(map[uint]float64)
+	var order []uint
+	for _, c := range criteria {
+		criteriaMap[c.CriteriaID] = c
+		key := uint(0)
+		if c.ParentCriteriaID != nil {
+			key = *c.ParentCriteriaID
+		}
+		if _, ok := sums[key]; !ok {
+			order = append(order, key)
+		}
+		sums[key] += local[c.CriteriaID]
+	}
+
+	for _, key := range order {
+		if math.Abs(sums[key]-1) > tolerance {
+			group := "kriteria utama"
+			if parent, ok := criteriaMap[key]; ok && key != 0 {
+				group = "sub-kriteria " + parent.Name
+			}
+			return fmt.Errorf("bobot %s harus berjumlah 1 (saat ini %.4f)", group, sums[key])
+		}
+	}
+	return nil
+}
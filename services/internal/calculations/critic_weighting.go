@@ -0,0 +1,116 @@
+package calculations
+
+import (
+	"errors"
+	"math"
+	"services/internal/models"
+)
+
+type CRITICWeighting interface {
+	ObjectiveWeighting
+}
+
+type criticWeighting struct{}
+
+func NewCRITICWeighting() CRITICWeighting {
+	return &criticWeighting{}
+}
+
+func (cw *criticWeighting) Name() string {
+	return WeightingCRITIC
+}
+
+func (cw *criticWeighting) CalculateWeights(
+	scoreSets [][]models.DMInputScore,
+	criteria []models.Criteria,
+	alternatives []models.Alternative,
+) (map[uint]float64, error) {
+	rows, err := stackScoreRows(scoreSets, criteria, alternatives)
+	if err != nil {
+		return nil, err
+	}
+
+	m := len(rows)
+	n := len(criteria)
+	if m < 2 {
+		return nil, errors.New("CRITIC: minimal dibutuhkan 2 baris penilaian")
+	}
+
+	// 1. Normalisasi min-max sesuai arah kriteria (benefit: x-min, cost: max-x)
+	r := make([][]float64, m)
+	for i := range r {
+		r[i] = make([]float64, n)
+	}
+	for j, c := range criteria {
+		minVal, maxVal := rows[0][j], rows[0][j]
+		for i := 1; i < m; i++ {
+			minVal = math.Min(minVal, rows[i][j])
+			maxVal = math.Max(maxVal, rows[i][j])
+		}
+		spread := maxVal - minVal
+		for i := 0; i < m; i++ {
+			if spread == 0 {
+				continue
+			}
+			if c.Type == "cost" {
+				r[i][j] = (maxVal - rows[i][j]) / spread
+			} else {
+				r[i][j] = (rows[i][j] - minVal) / spread
+			}
+		}
+	}
+
+	// 2. Rata-rata dan standar deviasi tiap kolom
+	mean := make([]float64, n)
+	stdDev := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			mean[j] += r[i][j]
+		}
+		mean[j] /= float64(m)
+		for i := 0; i < m; i++ {
+			stdDev[j] += math.Pow(r[i][j]-mean[j], 2)
+		}
+		stdDev[j] = math.Sqrt(stdDev[j] / float64(m))
+	}
+
+	// 3. Korelasi antar kriteria dan informasi C_j = σ_j Σ_k (1 - r_jk)
+	information := make([]float64, n)
+	totalInformation := 0.0
+	for j := 0; j < n; j++ {
+		conflict := 0.0
+		for k := 0; k < n; k++ {
+			conflict += 1 - correlation(r, mean, stdDev, j, k)
+		}
+		information[j] = stdDev[j] * conflict
+		totalInformation += information[j]
+	}
+
+	// 4. w_j = C_j / Σ C; matriks tanpa variasi sama sekali mendapat bobot rata
+	weights := make(map[uint]float64)
+	for j, c := range criteria {
+		if totalInformation > 0 {
+			weights[c.CriteriaID] = information[j] / totalInformation
+		} else {
+			weights[c.CriteriaID] = 1 / float64(n)
+		}
+	}
+
+	return weights, nil
+}
+
+// correlation is the Pearson correlation between columns j and k; a constant column correlates with nothing
+func correlation(r [][]float64, mean, stdDev []float64, j, k int) float64 {
+	if j == k {
+		return 1
+	}
+	if stdDev[j] == 0 || stdDev[k] == 0 {
+		return 0
+	}
+	covariance := 0.0
+	for i := range r {
+		covariance += (r[i][j] - mean[j]) * (r[i][k] - mean[k])
+	}
+	covariance /= float64(len(r))
+	return covariance / (stdDev[j] * stdDev[k])
+}
@@ -0,0 +1,79 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"services/internal/models"
+)
+
+type EntropyWeighting interface {
+	ObjectiveWeighting
+}
+
+type entropyWeighting struct{}
+
+func NewEntropyWeighting() EntropyWeighting {
+	return &entropyWeighting{}
+}
+
+func (ew *entropyWeighting) Name() string {
+	return WeightingEntropy
+}
+
+func (ew *entropyWeighting) CalculateWeights(
+	scoreSets [][]models.DMInputScore,
+	criteria []models.Criteria,
+	alternatives []models.Alternative,
+) (map[uint]float64, error) {
+	rows, err := stackScoreRows(scoreSets, criteria, alternatives)
+	if err != nil {
+		return nil, err
+	}
+
+	m := len(rows)
+	if m < 2 {
+		return nil, errors.New("Entropy: minimal dibutuhkan 2 baris penilaian")
+	}
+	k := 1 / math.Log(float64(m))
+
+	// 1. p_ij = x_ij / Σ_i x_ij, E_j = -k Σ p_ij ln p_ij, d_j = 1 - E_j
+	divergence := make([]float64, len(criteria))
+	totalDivergence := 0.0
+	for j, c := range criteria {
+		sum := 0.0
+		for i := 0; i < m; i++ {
+			if rows[i][j] < 0 {
+				return nil, fmt.Errorf("Entropy: skor kriteria %s tidak boleh negatif", c.Name)
+			}
+			sum += rows[i][j]
+		}
+
+		entropy := 1.0
+		if sum > 0 {
+			entropy = 0
+			for i := 0; i < m; i++ {
+				p := rows[i][j] / sum
+				if p > 0 {
+					entropy -= p * math.Log(p)
+				}
+			}
+			entropy *= k
+		}
+
+		divergence[j] = 1 - entropy
+		totalDivergence += divergence[j]
+	}
+
+	// 2. w_j = d_j / Σ d; matriks tanpa variasi sama sekali mendapat bobot rata
+	weights := make(map[uint]float64)
+	for j, c := range criteria {
+		if totalDivergence > 0 {
+			weights[c.CriteriaID] = divergence[j] / totalDivergence
+		} else {
+			weights[c.CriteriaID] = 1 / float64(len(criteria))
+		}
+	}
+
+	return weights, nil
+}
@@ -0,0 +1,78 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"services/internal/models"
+)
+
+// Group modes: AIP ranks every DM and aggregates the rankings, AIJ aggregates the
+// score matrices into one group matrix first and ranks it once
+const (
+	GroupModeAIP           = "AIP"
+	GroupModeAIJArithmetic = "AIJ_ARITHMETIC"
+	GroupModeAIJGeometric  = "AIJ_GEOMETRIC"
+)
+
+// IsAIJ reports whether the group mode aggregates the individual judgements
+func IsAIJ(mode string) bool {
+	return mode == GroupModeAIJArithmetic || mode == GroupModeAIJGeometric
+}
+
+// AggregateScoreMatrices combines the DMs' score matrices cell by cell with a DM-weighted
+// arithmetic or geometric mean. A DM without a score for a cell is left out of that cell.
+func AggregateScoreMatrices(
+	scoreSets [][]models.DMInputScore,
+	dmWeights []float64,
+	criteria []models.Criteria,
+	alternatives []models.Alternative,
+	mode string,
+) ([]models.DMInputScore, error) {
+	if len(scoreSets) == 0 || len(scoreSets) != len(dmWeights) {
+		return nil, errors.New("AIJ: data DM tidak lengkap")
+	}
+	if !IsAIJ(mode) {
+		return nil, fmt.Errorf("group mode %s does not aggregate score matrices", mode)
+	}
+
+	type cell struct{ alternativeID, criteriaID uint }
+	sums := make(map[cell]float64)
+	weightSums := make(map[cell]float64)
+
+	for k, scores := range scoreSets {
+		weight := dmWeightOrDefault(dmWeights[k])
+		for _, s := range scores {
+			key := cell{s.AlternativeID, s.CriteriaID}
+			if mode == GroupModeAIJGeometric {
+				if s.ScoreValue <= 0 {
+					return nil, fmt.Errorf("AIJ: rata-rata geometrik membutuhkan skor > 0 (alternatif %d, kriteria %d)", s.AlternativeID, s.CriteriaID)
+				}
+				sums[key] += weight * math.Log(s.ScoreValue)
+			} else {
+				sums[key] += weight * s.ScoreValue
+			}
+			weightSums[key] += weight
+		}
+	}
+
+	var group []models.DMInputScore
+	for _, a := range alternatives {
+		for _, c := range criteria {
+			key := cell{a.AlternativeID, c.CriteriaID}
+			if weightSums[key] == 0 {
+				continue
+			}
+			value := sums[key] / weightSums[key]
+			if mode == GroupModeAIJGeometric {
+				value = math.Exp(value)
+			}
+			group = append(group, models.DMInputScore{
+				AlternativeID: a.AlternativeID,
+				CriteriaID:    c.CriteriaID,
+				ScoreValue:    value,
+			})
+		}
+	}
+	return group, nil
+}
@@ -0,0 +1,260 @@
+package calculations
+
+import (
+	"errors"
+	"sort"
+)
+
+// kemenyExactLimit is the largest number of alternatives solved exactly; above it a local search is used
+const kemenyExactLimit = 10
+
+type KemenyCalculator interface {
+	GroupAggregator
+}
+
+type kemenyCalculator struct{}
+
+func NewKemenyCalculator() KemenyCalculator {
+	return &kemenyCalculator{}
+}
+
+func (kc *kemenyCalculator) Name() string {
+	return AggregationKemeny
+}
+
+// Aggregate finds the ranking with the smallest total weighted Kendall-tau distance to the DM rankings.
+// When it is solved exactly, alternatives whose relative order differs between equally optimal
+// consensus orders are tied and ranked by the tie policy like in the other aggregators; a tie block
+// spans the optimal order from the first to the last alternative of such pairs. The local search
+// cannot tell (Exact is false) and ranks every alternative apart.
+func (kc *kemenyCalculator) Aggregate(dmRankings []SingleDMRanking, ties TieOptions) (*AggregationResult, error) {
+	ids := alternativeIDs(dmRankings)
+	if len(ids) == 0 {
+		return nil, errors.New("gagal menghitung ranking Kemeny-Young")
+	}
+	if err := ties.validate(); err != nil {
+		return nil, err
+	}
+
+	// 1. prefs[i][j]: bobot DM yang menempatkan i di atas j
+	prefs := buildPairwisePreferences(dmRankings, ids)
+
+	// 2. Urutan optimal: eksak untuk alternatif sedikit, local search untuk sisanya
+	exact := len(ids) <= kemenyExactLimit
+	var optimal []uint
+	var ambiguous map[uint]map[uint]bool
+	if exact {
+		optimal, ambiguous = kemenyExact(ids, prefs)
+	} else {
+		optimal = kemenyLocalSearch(ids, prefs)
+	}
+	distance := kemenyDistance(optimal, prefs)
+
+	support := make(map[uint]float64)
+	for _, i := range ids {
+		for _, j := range ids {
+			if i != j {
+				support[i] += prefs[i][j]
+			}
+		}
+	}
+
+	// 3. Peringkat per blok seri; blok yang lebih awal lebih baik
+	block := kemenyTieBlocks(optimal, ambiguous)
+	compare := func(a, b uint) int { return block[b] - block[a] }
+	order, ranks, tied, err := rankOrder(optimal, compare, ties, firstPlaceVotes(dmRankings))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]AlternativeRank, 0, len(order))
+	for _, id := range order {
+		results = append(results, AlternativeRank{
+			AlternativeID: id,
+			Rank:          ranks[id],
+			Score:         support[id],
+			Tied:          tied[id],
+		})
+	}
+
+	return &AggregationResult{
+		Method:         AggregationKemeny,
+		Ranks:          results,
+		PairwiseMatrix: prefs,
+		KemenyDistance: &distance,
+		Exact:          &exact,
+	}, nil
+}
+
+// kemenyDistance is the weighted number of DM pairwise preferences the order disagrees with
+func kemenyDistance(order []uint, prefs map[uint]map[uint]float64) float64 {
+	distance := 0.0
+	for a := 0; a < len(order); a++ {
+		for b := a + 1; b < len(order); b++ {
+			distance += prefs[order[b]][order[a]]
+		}
+	}
+	return distance
+}
+
+// kemenyExact solves the problem with dynamic programming over subsets: best[S] is the smallest
+// distance of ordering the alternatives in S among themselves. It also returns the pairs whose
+// relative order is not the same in every optimal order.
+func kemenyExact(ids []uint, prefs map[uint]map[uint]float64) ([]uint, map[uint]map[uint]bool) {
+	n := len(ids)
+	full := 1<<n - 1
+	best := make([]float64, full+1)
+	last := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		best[mask] = -1
+		for v := 0; v < n; v++ {
+			if mask&(1<<v) == 0 {
+				continue
+			}
+			rest := mask &^ (1 << v)
+			// v ditempatkan setelah semua alternatif di rest
+			cost := best[rest]
+			for u := 0; u < n; u++ {
+				if rest&(1<<u) != 0 {
+					cost += prefs[ids[v]][ids[u]]
+				}
+			}
+			if best[mask] < 0 || cost < best[mask]-scoreEpsilon {
+				best[mask] = cost
+				last[mask] = v
+			}
+		}
+	}
+
+	order := make([]uint, n)
+	for mask, pos := full, n-1; mask != 0; pos-- {
+		v := last[mask]
+		order[pos] = ids[v]
+		mask &^= 1 << v
+	}
+	return order, kemenyAmbiguous(ids, prefs, best)
+}
+
+// kemenyAmbiguous finds the pairs ordered both ways by optimal orders. A set S can be the top |S|
+// positions of an optimal order iff best[S] + best[rest] + the disagreements between S and the rest
+// equals the optimum; i can then be placed above j whenever such an S holds i but not j.
+func kemenyAmbiguous(ids []uint, prefs map[uint]map[uint]float64, best []float64) map[uint]map[uint]bool {
+	n := len(ids)
+	full := 1<<n - 1
+	above := make([][]bool, n)
+	for i := range above {
+		above[i] = make([]bool, n)
+	}
+	for mask := 1; mask < full; mask++ {
+		rest := full &^ mask
+		cost := best[mask] + best[rest]
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if mask&(1<<u) != 0 && rest&(1<<v) != 0 {
+					cost += prefs[ids[v]][ids[u]]
+				}
+			}
+		}
+		if cost > best[full]+scoreEpsilon {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if mask&(1<<i) != 0 && rest&(1<<j) != 0 {
+					above[i][j] = true
+				}
+			}
+		}
+	}
+
+	ambiguous := make(map[uint]map[uint]bool)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && above[i][j] && above[j][i] {
+				if ambiguous[ids[i]] == nil {
+					ambiguous[ids[i]] = make(map[uint]bool)
+				}
+				ambiguous[ids[i]][ids[j]] = true
+			}
+		}
+	}
+	return ambiguous
+}
+
+// kemenyTieBlocks numbers the positions of the order so that both alternatives of every ambiguous
+// pair, and everything placed between them, fall in the same block
+func kemenyTieBlocks(order []uint, ambiguous map[uint]map[uint]bool) map[uint]int {
+	position := make(map[uint]int)
+	for i, id := range order {
+		position[id] = i
+	}
+
+	block := make(map[uint]int)
+	current, end := -1, -1
+	for i, id := range order {
+		if i > end {
+			current++
+			end = i
+		}
+		block[id] = current
+		for other := range ambiguous[id] {
+			if position[other] > end {
+				end = position[other]
+			}
+		}
+	}
+	return block
+}
+
+// kemenyLocalSearch starts from the order by pairwise support and keeps moving single
+// alternatives to a better position until no move lowers the distance.
+func kemenyLocalSearch(ids []uint, prefs map[uint]map[uint]float64) []uint {
+	support := make(map[uint]float64)
+	for _, i := range ids {
+		for _, j := range ids {
+			support[i] += prefs[i][j]
+		}
+	}
+	order := append([]uint(nil), ids...)
+	sort.SliceStable(order, func(a, b int) bool {
+		return support[order[a]] > support[order[b]]
+	})
+
+	improved := true
+	for improved {
+		improved = false
+		for from := 0; from < len(order); from++ {
+			bestTo, bestGain := from, 0.0
+			// gain dihitung bertahap saat elemen digeser satu posisi demi satu posisi
+			gain := 0.0
+			for to := from - 1; to >= 0; to-- {
+				gain += prefs[order[from]][order[to]] - prefs[order[to]][order[from]]
+				if gain > bestGain+scoreEpsilon {
+					bestTo, bestGain = to, gain
+				}
+			}
+			gain = 0.0
+			for to := from + 1; to < len(order); to++ {
+				gain += prefs[order[to]][order[from]] - prefs[order[from]][order[to]]
+				if gain > bestGain+scoreEpsilon {
+					bestTo, bestGain = to, gain
+				}
+			}
+			if bestTo != from {
+				moveElement(order, from, bestTo)
+				improved = true
+			}
+		}
+	}
+	return order
+}
+
+func moveElement(order []uint, from, to int) {
+	v := order[from]
+	if from < to {
+		copy(order[from:to], order[from+1:to+1])
+	} else {
+		copy(order[to+1:from+1], order[to:from])
+	}
+	order[to] = v
+}
@@ -0,0 +1,212 @@
+package calculations
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// dmOrder builds a DM ranking from alternative IDs listed best first
+func dmOrder(dmID uint, weight float64, order ...uint) SingleDMRanking {
+	ranking := SingleDMRanking{DMID: dmID, DMWeight: weight}
+	for pos, id := range order {
+		ranking.RankedList = append(ranking.RankedList, AlternativeRank{AlternativeID: id, Rank: pos + 1})
+	}
+	return ranking
+}
+
+// randomRankings draws DM rankings of n alternatives with random weights from a fixed seed
+func randomRankings(seed uint64, n, dms int) []SingleDMRanking {
+	rng := rand.New(rand.NewPCG(seed, 0))
+	var rankings []SingleDMRanking
+	for d := 0; d < dms; d++ {
+		order := make([]uint, n)
+		for i := range order {
+			order[i] = uint(i + 1)
+		}
+		rng.Shuffle(n, func(i, j int) { order[i], order[j] = order[j], order[i] })
+		rankings = append(rankings, dmOrder(uint(d+1), float64(1+rng.IntN(3)), order...))
+	}
+	return rankings
+}
+
+// bruteForceKemeny tries every permutation and returns the smallest distance
+func bruteForceKemeny(ids []uint, prefs map[uint]map[uint]float64) float64 {
+	best := math.Inf(1)
+	order := append([]uint(nil), ids...)
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(order) {
+			best = math.Min(best, kemenyDistance(order, prefs))
+			return
+		}
+		for i := k; i < len(order); i++ {
+			order[k], order[i] = order[i], order[k]
+			permute(k + 1)
+			order[k], order[i] = order[i], order[k]
+		}
+	}
+	permute(0)
+	return best
+}
+
+func TestKemenyExactMatchesBruteForce(t *testing.T) {
+	for n := 2; n <= 7; n++ {
+		for seed := uint64(1); seed <= 5; seed++ {
+			rankings := randomRankings(seed*100+uint64(n), n, 5)
+			ids := alternativeIDs(rankings)
+			prefs := buildPairwisePreferences(rankings, ids)
+
+			order, _ := kemenyExact(ids, prefs)
+			got := kemenyDistance(order, prefs)
+			if want := bruteForceKemeny(ids, prefs); math.Abs(got-want) > scoreEpsilon {
+				t.Errorf("n=%d seed=%d: exact distance %.2f, brute force %.2f", n, seed, got, want)
+			}
+		}
+	}
+}
+
+func TestKemenyLocalSearchAgainstExact(t *testing.T) {
+	tests := []struct {
+		name     string
+		rankings []SingleDMRanking
+	}{
+		{
+			name: "unanimous",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 1, 3, 1, 2, 4),
+				dmOrder(2, 1, 3, 1, 2, 4),
+			},
+		},
+		{
+			name: "weighted majority",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 3, 1, 2, 3, 4, 5),
+				dmOrder(2, 1, 5, 4, 3, 2, 1),
+				dmOrder(3, 1, 2, 1, 3, 5, 4),
+			},
+		},
+		{
+			name: "Condorcet cycle",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 1, 1, 2, 3),
+				dmOrder(2, 1, 2, 3, 1),
+				dmOrder(3, 1, 3, 1, 2),
+			},
+		},
+		{name: "random 6 alternatives", rankings: randomRankings(7, 6, 4)},
+		{name: "random 8 alternatives", rankings: randomRankings(11, 8, 5)},
+		{name: "random 10 alternatives", rankings: randomRankings(13, 10, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := alternativeIDs(tt.rankings)
+			prefs := buildPairwisePreferences(tt.rankings, ids)
+
+			exactOrder, _ := kemenyExact(ids, prefs)
+			exact := kemenyDistance(exactOrder, prefs)
+			local := kemenyDistance(kemenyLocalSearch(ids, prefs), prefs)
+
+			// Local search tidak pernah lebih baik dari optimum; pada kasus ini keduanya harus sama
+			if local < exact-scoreEpsilon {
+				t.Fatalf("local search distance %.2f is below the exact optimum %.2f", local, exact)
+			}
+			if math.Abs(local-exact) > scoreEpsilon {
+				t.Errorf("local search distance %.2f, exact %.2f", local, exact)
+			}
+		})
+	}
+}
+
+func TestKemenyTies(t *testing.T) {
+	tests := []struct {
+		name     string
+		rankings []SingleDMRanking
+		ties     TieOptions
+		tied     map[uint]bool
+		ranks    map[uint]int
+	}{
+		{
+			name: "unique optimum",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 1, 1, 2, 3),
+				dmOrder(2, 1, 1, 2, 3),
+				dmOrder(3, 1, 2, 1, 3),
+			},
+			tied:  map[uint]bool{},
+			ranks: map[uint]int{1: 1, 2: 2, 3: 3},
+		},
+		{
+			name: "opposite rankings of equal weight",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 1, 1, 2, 3),
+				dmOrder(2, 1, 3, 2, 1),
+			},
+			tied:  map[uint]bool{1: true, 2: true, 3: true},
+			ranks: map[uint]int{1: 1, 2: 1, 3: 1},
+		},
+		{
+			// 1 selalu terbaik; 2 dan 3 bertukar posisi dengan bobot yang sama
+			name: "one ambiguous pair",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 1, 1, 2, 3),
+				dmOrder(2, 1, 1, 3, 2),
+			},
+			tied:  map[uint]bool{2: true, 3: true},
+			ranks: map[uint]int{1: 1, 2: 2, 3: 2},
+		},
+		{
+			name: "one ambiguous pair, fractional",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 1, 1, 2, 3),
+				dmOrder(2, 1, 1, 3, 2),
+			},
+			ties:  TieOptions{Policy: TiePolicyFractional},
+			tied:  map[uint]bool{2: true, 3: true},
+			ranks: map[uint]int{1: 1, 2: 2, 3: 2},
+		},
+		{
+			name: "one ambiguous pair, tie-break by alternative ID",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 1, 1, 3, 2),
+				dmOrder(2, 1, 1, 2, 3),
+			},
+			ties:  TieOptions{Policy: TiePolicyTieBreak, BreakRule: TieBreakAlternativeID},
+			tied:  map[uint]bool{2: true, 3: true},
+			ranks: map[uint]int{1: 1, 2: 2, 3: 3},
+		},
+		{
+			// Semua pasangan seri; alternatif 1 tidak pernah di posisi pertama, jadi turun ke akhir meski ID-nya terkecil
+			name: "all tied, tie-break by first places",
+			rankings: []SingleDMRanking{
+				dmOrder(1, 1, 2, 1, 3),
+				dmOrder(2, 2, 3, 1, 2),
+				dmOrder(3, 1, 2, 1, 3),
+			},
+			ties:  TieOptions{Policy: TiePolicyTieBreak, BreakRule: TieBreakFirstPlaces},
+			tied:  map[uint]bool{1: true, 2: true, 3: true},
+			ranks: map[uint]int{1: 3, 2: 1, 3: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewKemenyCalculator().Aggregate(tt.rankings, tt.ties)
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+			if !*result.Exact {
+				t.Fatal("expected an exact solution")
+			}
+			for _, r := range result.Ranks {
+				if r.Tied != tt.tied[r.AlternativeID] {
+					t.Errorf("alternative %d tied = %v, want %v", r.AlternativeID, r.Tied, tt.tied[r.AlternativeID])
+				}
+				if r.Rank != tt.ranks[r.AlternativeID] {
+					t.Errorf("alternative %d rank = %d, want %d", r.AlternativeID, r.Rank, tt.ranks[r.AlternativeID])
+				}
+			}
+		})
+	}
+}
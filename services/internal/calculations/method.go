@@ -0,0 +1,79 @@
+package calculations
+
+import (
+	"services/internal/models"
+	"sort"
+	"sync"
+)
+
+const (
+	MethodTOPSIS = "TOPSIS"
+	MethodSAW    = "SAW"
+	MethodWP     = "WP"
+	MethodAHP    = "AHP"
+)
+
+// RankingOptions carries the project settings a decision method may honour
+type RankingOptions struct {
+	// Normalization is used by TOPSIS (VECTOR when empty); SAW, WP and AHP have their own fixed scheme
+	Normalization string
+	// Ties decides how equal scores are ranked (SHARED when empty)
+	Ties TieOptions
+}
+
+// DecisionMethod ranks the alternatives for a single decision maker. Methods, aggregators and weightings
+// do not log: SMAA and the sensitivity analysis call them thousands of times per request, so the
+// results are logged and traced by the caller.
+type DecisionMethod interface {
+	Name() string
+	CalculateRanking(
+		scores []models.DMInputScore,
+		criteria []models.Criteria,
+		alternatives []models.Alternative,
+		weights map[uint]float64,
+		options RankingOptions,
+	) ([]TOPSISRank, error)
+}
+
+type MethodRegistry interface {
+	Register(method DecisionMethod)
+	Get(name string) (DecisionMethod, bool)
+	Names() []string
+}
+
+type methodRegistry struct {
+	mu      sync.RWMutex
+	methods map[string]DecisionMethod
+}
+
+func NewMethodRegistry(methods ...DecisionMethod) MethodRegistry {
+	registry := &methodRegistry{methods: make(map[string]DecisionMethod)}
+	for _, m := range methods {
+		registry.Register(m)
+	}
+	return registry
+}
+
+func (r *methodRegistry) Register(method DecisionMethod) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.methods[method.Name()] = method
+}
+
+func (r *methodRegistry) Get(name string) (DecisionMethod, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	method, ok := r.methods[name]
+	return method, ok
+}
+
+func (r *methodRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.methods))
+	for name := range r.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,106 @@
+package calculations
+
+import (
+	"fmt"
+	"services/internal/models"
+)
+
+// Missing score policies: BLOCK refuses to calculate, IMPUTE_MEAN/IMPUTE_MEDIAN fill the empty cells
+// from the criterion and EXCLUDE_DM leaves a DM out of every criterion they did not score completely
+const (
+	MissingScoreBlock        = "BLOCK"
+	MissingScoreImputeMean   = "IMPUTE_MEAN"
+	MissingScoreImputeMedian = "IMPUTE_MEDIAN"
+	MissingScoreExcludeDM    = "EXCLUDE_DM"
+)
+
+// ScoreCell is one cell of the alternatives × leaf criteria score grid
+type ScoreCell struct {
+	AlternativeID uint
+	CriteriaID    uint
+}
+
+// MissingScores lists the cells of the alternatives × leaf criteria grid without a score,
+// alternative by alternative in the order of the arguments
+func MissingScores(scores []models.DMInputScore, leaves []models.Criteria, alternatives []models.Alternative) []ScoreCell {
+	filled := make(map[ScoreCell]bool)
+	for _, s := range scores {
+		filled[ScoreCell{s.AlternativeID, s.CriteriaID}] = true
+	}
+
+	var missing []ScoreCell
+	for _, a := range alternatives {
+		for _, c := range leaves {
+			cell := ScoreCell{a.AlternativeID, c.CriteriaID}
+			if !filled[cell] {
+				missing = append(missing, cell)
+			}
+		}
+	}
+	return missing
+}
+
+// ImputeScores returns the DM's scores with every missing cell filled by the mean or median of the
+// criterion. The DM's own scores on the criterion are used; a criterion the DM never scored falls back
+// to the scores of all DMs in pool.
+func ImputeScores(
+	scores []models.DMInputScore,
+	pool [][]models.DMInputScore,
+	leaves []models.Criteria,
+	alternatives []models.Alternative,
+	policy string,
+) ([]models.DMInputScore, error) {
+	missing := MissingScores(scores, leaves, alternatives)
+	if len(missing) == 0 {
+		return scores, nil
+	}
+
+	own := scoresByCriteria(scores)
+	pooled := make(map[uint][]float64)
+	for _, set := range pool {
+		for id, values := range scoresByCriteria(set) {
+			pooled[id] = append(pooled[id], values...)
+		}
+	}
+
+	names := make(map[uint]string)
+	for _, c := range leaves {
+		names[c.CriteriaID] = c.Name
+	}
+
+	imputed := make([]models.DMInputScore, len(scores), len(scores)+len(missing))
+	copy(imputed, scores)
+	for _, cell := range missing {
+		values := own[cell.CriteriaID]
+		if len(values) == 0 {
+			values = pooled[cell.CriteriaID]
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("kriteria %s belum memiliki skor sama sekali sehingga tidak dapat diimputasi", names[cell.CriteriaID])
+		}
+
+		var value float64
+		switch policy {
+		case MissingScoreImputeMean:
+			value = Mean(values)
+		case MissingScoreImputeMedian:
+			value = Median(values)
+		default:
+			return nil, fmt.Errorf("missing score policy %s does not impute", policy)
+		}
+		imputed = append(imputed, models.DMInputScore{
+			AlternativeID: cell.AlternativeID,
+			CriteriaID:    cell.CriteriaID,
+			ScoreValue:    value,
+		})
+	}
+	return imputed, nil
+}
+
+func scoresByCriteria(scores []models.DMInputScore) map[uint][]float64 {
+	values := make(map[uint][]float64)
+	for _, s := range scores {
+		values[s.CriteriaID] = append(values[s.CriteriaID], s.ScoreValue)
+	}
+	return values
+}
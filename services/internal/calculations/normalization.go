@@ -0,0 +1,73 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+)
+
+// Normalization schemes for the decision matrix columns
+const (
+	NormalizationVector    = "VECTOR"     // r = x / √(Σx²)
+	NormalizationLinearMax = "LINEAR_MAX" // r = x / max
+	NormalizationMinMax    = "MIN_MAX"    // r = (x - min) / (max - min)
+	NormalizationLinearSum = "LINEAR_SUM" // r = x / Σx
+)
+
+// NormalizeColumn normalizes the scores of one criterion with the given scheme (VECTOR when empty).
+// Every scheme keeps the order of the values, so the direction of the criterion is still handled by the method.
+func NormalizeColumn(method string, values []float64) ([]float64, error) {
+	normalized := make([]float64, len(values))
+	if len(values) == 0 {
+		return normalized, nil
+	}
+
+	switch method {
+	case "", NormalizationVector:
+		sumOfSquares := 0.0
+		for _, x := range values {
+			sumOfSquares += x * x
+		}
+		divideBy(normalized, values, math.Sqrt(sumOfSquares))
+
+	case NormalizationLinearMax:
+		max := values[0]
+		for _, x := range values {
+			max = math.Max(max, x)
+		}
+		divideBy(normalized, values, max)
+
+	case NormalizationMinMax:
+		min, max := values[0], values[0]
+		for _, x := range values {
+			min = math.Min(min, x)
+			max = math.Max(max, x)
+		}
+		// Kolom tanpa variasi tidak membedakan alternatif, nilainya dibiarkan 0
+		if max != min {
+			for i, x := range values {
+				normalized[i] = (x - min) / (max - min)
+			}
+		}
+
+	case NormalizationLinearSum:
+		sum := 0.0
+		for _, x := range values {
+			sum += x
+		}
+		divideBy(normalized, values, sum)
+
+	default:
+		return nil, fmt.Errorf("normalization %s is not supported", method)
+	}
+
+	return normalized, nil
+}
+
+func divideBy(dst, values []float64, factor float64) {
+	if factor == 0 {
+		return
+	}
+	for i, x := range values {
+		dst[i] = x / factor
+	}
+}
@@ -0,0 +1,98 @@
+package calculations
+
+import (
+	"errors"
+	"services/internal/models"
+	"sort"
+	"sync"
+)
+
+const (
+	WeightingEntropy = "ENTROPY"
+	WeightingCRITIC  = "CRITIC"
+)
+
+// ObjectiveWeighting derives criteria weights from the dispersion of the score matrix.
+// Every score set is one DM's matrix; passing several sets pools them into one matrix
+// where each (DM, alternative) pair becomes a row.
+type ObjectiveWeighting interface {
+	Name() string
+	CalculateWeights(
+		scoreSets [][]models.DMInputScore,
+		criteria []models.Criteria,
+		alternatives []models.Alternative,
+	) (map[uint]float64, error)
+}
+
+type WeightingRegistry interface {
+	Register(weighting ObjectiveWeighting)
+	Get(name string) (ObjectiveWeighting, bool)
+	Names() []string
+}
+
+type weightingRegistry struct {
+	mu         sync.RWMutex
+	weightings map[string]ObjectiveWeighting
+}
+
+func NewWeightingRegistry(weightings ...ObjectiveWeighting) WeightingRegistry {
+	registry := &weightingRegistry{weightings: make(map[string]ObjectiveWeighting)}
+	for _, w := range weightings {
+		registry.Register(w)
+	}
+	return registry
+}
+
+func (r *weightingRegistry) Register(weighting ObjectiveWeighting) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.weightings[weighting.Name()] = weighting
+}
+
+func (r *weightingRegistry) Get(name string) (ObjectiveWeighting, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	weighting, ok := r.weightings[name]
+	return weighting, ok
+}
+
+func (r *weightingRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.weightings))
+	for name := range r.weightings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stackScoreRows builds the pooled decision matrix: one row per (score set, alternative),
+// one column per criterion in the given order.
+func stackScoreRows(scoreSets [][]models.DMInputScore, criteria []models.Criteria, alternatives []models.Alternative) ([][]float64, error) {
+	if len(criteria) == 0 || len(alternatives) == 0 || len(scoreSets) == 0 {
+		return nil, errors.New("pembobotan objektif: data tidak lengkap")
+	}
+
+	column := make(map[uint]int)
+	for j, c := range criteria {
+		column[c.CriteriaID] = j
+	}
+
+	var rows [][]float64
+	for _, scores := range scoreSets {
+		rowOf := make(map[uint]int)
+		for _, a := range alternatives {
+			rowOf[a.AlternativeID] = len(rows)
+			rows = append(rows, make([]float64, len(criteria)))
+		}
+		for _, s := range scores {
+			i, okRow := rowOf[s.AlternativeID]
+			j, okCol := column[s.CriteriaID]
+			if okRow && okCol {
+				rows[i][j] = s.ScoreValue
+			}
+		}
+	}
+	return rows, nil
+}
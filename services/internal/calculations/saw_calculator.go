@@ -0,0 +1,106 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"services/internal/models"
+)
+
+type SAWCalculator interface {
+	DecisionMethod
+}
+
+type sawCalculator struct{}
+
+func NewSAWCalculator() SAWCalculator {
+	return &sawCalculator{}
+}
+
+func (calc *sawCalculator) Name() string {
+	return MethodSAW
+}
+
+func (calc *sawCalculator) CalculateRanking(
+	scores []models.DMInputScore,
+	criteria []models.Criteria,
+	alternatives []models.Alternative,
+	weights map[uint]float64,
+	options RankingOptions,
+) ([]TOPSISRank, error) {
+
+	if len(criteria) == 0 || len(alternatives) == 0 || len(scores) == 0 {
+		return nil, errors.New("SAW: data tidak lengkap")
+	}
+
+	// 1. Build decision matrix
+	scoreMatrix := make(map[uint]map[uint]float64)
+	for _, a := range alternatives {
+		scoreMatrix[a.AlternativeID] = make(map[uint]float64)
+	}
+	for _, s := range scores {
+		if _, ok := scoreMatrix[s.AlternativeID]; ok {
+			scoreMatrix[s.AlternativeID][s.CriteriaID] = s.ScoreValue
+		}
+	}
+
+	// 2. Linear normalization (R matrix)
+	// benefit: r_ij = x_ij / max_j, cost: r_ij = min_j / x_ij
+	R := make(map[uint]map[uint]float64)
+	for _, a := range alternatives {
+		R[a.AlternativeID] = make(map[uint]float64)
+	}
+
+	for _, c := range criteria {
+		var max, min float64
+		first := true
+		for _, a := range alternatives {
+			x := scoreMatrix[a.AlternativeID][c.CriteriaID]
+			if first {
+				max = x
+				min = x
+				first = false
+			}
+			if x > max {
+				max = x
+			}
+			if x < min {
+				min = x
+			}
+		}
+
+		for _, a := range alternatives {
+			x := scoreMatrix[a.AlternativeID][c.CriteriaID]
+			var r_ij float64
+			if c.Type == "cost" {
+				if x <= 0 {
+					return nil, fmt.Errorf("SAW: skor kriteria cost %s harus > 0", c.Name)
+				}
+				r_ij = min / x
+			} else if max != 0 {
+				r_ij = x / max
+			}
+			R[a.AlternativeID][c.CriteriaID] = r_ij
+		}
+	}
+
+	// 3. Preference value V_i = Σ w_j * r_ij
+	var results []TOPSISRank
+	for _, a := range alternatives {
+		V_i := 0.0
+		for _, c := range criteria {
+			V_i += weights[c.CriteriaID] * R[a.AlternativeID][c.CriteriaID]
+		}
+		results = append(results, TOPSISRank{
+			AlternativeID: a.AlternativeID,
+			FinalScore:    V_i,
+		})
+	}
+
+	// Sort by FinalScore descending with tie-aware ranks
+	results, err := rankMethodResults(results, options.Ties)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
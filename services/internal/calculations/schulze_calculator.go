@@ -0,0 +1,113 @@
+package calculations
+
+import (
+	"errors"
+	"math"
+)
+
+type SchulzeCalculator interface {
+	GroupAggregator
+}
+
+type schulzeCalculator struct{}
+
+func NewSchulzeCalculator() SchulzeCalculator {
+	return &schulzeCalculator{}
+}
+
+func (sc *schulzeCalculator) Name() string {
+	return AggregationSchulze
+}
+
+func (sc *schulzeCalculator) Aggregate(dmRankings []SingleDMRanking, ties TieOptions) (*AggregationResult, error) {
+	ids := alternativeIDs(dmRankings)
+	if len(ids) == 0 {
+		return nil, errors.New("gagal menghitung ranking Schulze")
+	}
+
+	// 1. d[i][j]: bobot DM yang menempatkan i di atas j
+	prefs := buildPairwisePreferences(dmRankings, ids)
+
+	// 2. Kekuatan jalur terkuat (Floyd-Warshall varian widest path)
+	paths := make(map[uint]map[uint]float64)
+	for _, i := range ids {
+		paths[i] = make(map[uint]float64)
+		for _, j := range ids {
+			if i != j && prefs[i][j] > prefs[j][i] {
+				paths[i][j] = prefs[i][j]
+			} else if i != j {
+				paths[i][j] = 0
+			}
+		}
+	}
+	for _, k := range ids {
+		for _, i := range ids {
+			if i == k {
+				continue
+			}
+			for _, j := range ids {
+				if j == i || j == k {
+					continue
+				}
+				paths[i][j] = math.Max(paths[i][j], math.Min(paths[i][k], paths[k][j]))
+			}
+		}
+	}
+
+	// 3. i mengalahkan j bila p[i][j] > p[j][i]; skor = jumlah alternatif yang dikalahkan
+	wins := make(map[uint]float64)
+	for _, i := range ids {
+		for _, j := range ids {
+			if i != j && compareScores(paths[i][j], paths[j][i]) > 0 {
+				wins[i]++
+			}
+		}
+	}
+
+	// Relasi Schulze transitif, jadi urutan jumlah kemenangan konsisten dengan jalur terkuat
+	compare := func(a, b uint) int { return compareScores(wins[a], wins[b]) }
+	order, ranks, tied, err := rankOrder(ids, compare, ties, firstPlaceVotes(dmRankings))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []AlternativeRank
+	for _, id := range order {
+		results = append(results, AlternativeRank{
+			AlternativeID: id,
+			Rank:          ranks[id],
+			Score:         wins[id],
+			Tied:          tied[id],
+		})
+	}
+
+	winner := condorcetWinner(ids, prefs)
+	hasWinner := winner != nil
+
+	return &AggregationResult{
+		Method:             AggregationSchulze,
+		Ranks:              results,
+		PairwiseMatrix:     prefs,
+		StrongestPaths:     paths,
+		CondorcetWinner:    winner,
+		HasCondorcetWinner: &hasWinner,
+	}, nil
+}
+
+// condorcetWinner returns the alternative that beats every other one head-to-head, if any
+func condorcetWinner(ids []uint, prefs map[uint]map[uint]float64) *uint {
+	for _, i := range ids {
+		beatsAll := true
+		for _, j := range ids {
+			if i != j && prefs[i][j] <= prefs[j][i] {
+				beatsAll = false
+				break
+			}
+		}
+		if beatsAll {
+			winner := i
+			return &winner
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package calculations
+
+import "services/internal/models"
+
+// ShiftWeight sets the global weight of one criterion to target and rescales the other criteria
+// proportionally, so the weights of the given criteria still sum to 1. When the other criteria have
+// no weight left to scale, the remainder is shared equally between them.
+func ShiftWeight(weights map[uint]float64, criteria []models.Criteria, criteriaID uint, target float64) map[uint]float64 {
+	others := 0.0
+	otherCount := 0
+	for _, c := range criteria {
+		if c.CriteriaID != criteriaID {
+			others += weights[c.CriteriaID]
+			otherCount++
+		}
+	}
+
+	shifted := make(map[uint]float64, len(weights))
+	for id, w := range weights {
+		shifted[id] = w
+	}
+	for _, c := range criteria {
+		switch {
+		case c.CriteriaID == criteriaID:
+			shifted[c.CriteriaID] = target
+		case others > 0:
+			shifted[c.CriteriaID] = weights[c.CriteriaID] * (1 - target) / others
+		default:
+			shifted[c.CriteriaID] = (1 - target) / float64(otherCount)
+		}
+	}
+	return shifted
+}
+
+// BisectReversal narrows the weight interval [left, right] down to tolerance around the point where
+// changed starts to report true. changed(left) must be false and changed(right) true; the returned
+// left still has the old ranking and right already has the new one.
+func BisectReversal(left, right, tolerance float64, changed func(weight float64) (bool, error)) (float64, float64, error) {
+	for right-left > tolerance {
+		mid := (left + right) / 2
+		ok, err := changed(mid)
+		if err != nil {
+			return 0, 0, err
+		}
+		if ok {
+			right = mid
+		} else {
+			left = mid
+		}
+	}
+	return left, right, nil
+}
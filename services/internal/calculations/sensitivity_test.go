@@ -0,0 +1,98 @@
+package calculations
+
+import (
+	"math"
+	"services/internal/models"
+	"testing"
+)
+
+func TestShiftWeightKeepsSumOfOne(t *testing.T) {
+	criteria := []models.Criteria{{CriteriaID: 1}, {CriteriaID: 2}, {CriteriaID: 3}}
+
+	tests := []struct {
+		name    string
+		weights map[uint]float64
+		target  float64
+		want    map[uint]float64
+	}{
+		{
+			name:    "others rescaled proportionally",
+			weights: map[uint]float64{1: 0.5, 2: 0.3, 3: 0.2},
+			target:  0.75,
+			want:    map[uint]float64{1: 0.75, 2: 0.15, 3: 0.1},
+		},
+		{
+			name:    "target of zero",
+			weights: map[uint]float64{1: 0.5, 2: 0.3, 3: 0.2},
+			target:  0,
+			want:    map[uint]float64{1: 0, 2: 0.6, 3: 0.4},
+		},
+		{
+			// Kriteria lain tanpa bobot berbagi sisa secara rata
+			name:    "others without weight",
+			weights: map[uint]float64{1: 1, 2: 0, 3: 0},
+			target:  0.4,
+			want:    map[uint]float64{1: 0.4, 2: 0.3, 3: 0.3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shifted := ShiftWeight(tt.weights, criteria, 1, tt.target)
+			sum := 0.0
+			for _, c := range criteria {
+				sum += shifted[c.CriteriaID]
+				if math.Abs(shifted[c.CriteriaID]-tt.want[c.CriteriaID]) > scoreEpsilon {
+					t.Errorf("weight of criteria %d = %g, want %g", c.CriteriaID, shifted[c.CriteriaID], tt.want[c.CriteriaID])
+				}
+			}
+			if math.Abs(sum-1) > scoreEpsilon {
+				t.Errorf("weights sum to %g, want 1", sum)
+			}
+		})
+	}
+}
+
+func TestBisectReversalFindsTopRankFlip(t *testing.T) {
+	// Alternatif 1 unggul di kriteria 1, alternatif 2 di kriteria 2: menaikkan bobot kriteria 2 membalik juara
+	criteria := []models.Criteria{{CriteriaID: 1, Type: "benefit"}, {CriteriaID: 2, Type: "benefit"}}
+	alternatives := []models.Alternative{{AlternativeID: 1}, {AlternativeID: 2}}
+	scores := []models.DMInputScore{
+		{AlternativeID: 1, CriteriaID: 1, ScoreValue: 9},
+		{AlternativeID: 1, CriteriaID: 2, ScoreValue: 2},
+		{AlternativeID: 2, CriteriaID: 1, ScoreValue: 3},
+		{AlternativeID: 2, CriteriaID: 2, ScoreValue: 8},
+	}
+	base := map[uint]float64{1: 0.7, 2: 0.3}
+	saw := NewSAWCalculator()
+
+	topAt := func(weight float64) uint {
+		ranks, err := saw.CalculateRanking(scores, criteria, alternatives, ShiftWeight(base, criteria, 2, weight), RankingOptions{})
+		if err != nil {
+			t.Fatalf("CalculateRanking: %v", err)
+		}
+		return ranks[0].AlternativeID
+	}
+	if topAt(0) != 1 || topAt(1) != 2 {
+		t.Fatalf("expected alternative 1 on top at weight 0 and alternative 2 at weight 1")
+	}
+
+	const tolerance = 1e-6
+	left, right, err := BisectReversal(0, 1, tolerance, func(weight float64) (bool, error) {
+		return topAt(weight) != 1, nil
+	})
+	if err != nil {
+		t.Fatalf("BisectReversal: %v", err)
+	}
+	if right-left > tolerance {
+		t.Errorf("interval [%g, %g] is wider than the tolerance", left, right)
+	}
+	if topAt(left) != 1 || topAt(right) != 2 {
+		t.Errorf("top rank does not flip inside [%g, %g]", left, right)
+	}
+
+	// SAW: 9/9·(1-w) + 2/8·w = 3/9·(1-w) + 8/8·w  =>  w = 8/17
+	if want := 8.0 / 17; math.Abs((left+right)/2-want) > tolerance {
+		t.Errorf("threshold %g, want %g", (left+right)/2, want)
+	}
+}
@@ -0,0 +1,221 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"services/internal/models"
+	"sync"
+)
+
+// smaaMaxAttempts caps the rejection sampling of one weight vector within the bounds
+const smaaMaxAttempts = 10000
+
+// WeightBound limits the global weight of one criterion during SMAA sampling
+type WeightBound struct {
+	Min float64
+	Max float64
+}
+
+// SMAAConfig describes one SMAA run. Every sample draws its own random stream from (Seed, sample index),
+// so the result only depends on the seed and never on the number of workers.
+type SMAAConfig struct {
+	Samples int
+	Seed    uint64
+	Workers int
+
+	Criteria     []models.Criteria
+	Alternatives []models.Alternative
+	ScoreSets    [][]models.DMInputScore
+	DMIDs        []uint
+	DMWeights    []float64
+
+	// Bounds: kriteria tanpa batas diambil dari [0, 1]
+	Bounds map[uint]WeightBound
+	// ScorePerturbation mengganggu setiap skor secara seragam dalam ±ScorePerturbation (relatif)
+	ScorePerturbation float64
+
+	Method     DecisionMethod
+	Aggregator GroupAggregator
+	Options    RankingOptions
+}
+
+type SMAAResult struct {
+	Samples int
+	Seed    uint64
+	// RankAcceptability[alt][r-1]: porsi sampel yang menempatkan alternatif pada peringkat r
+	RankAcceptability map[uint][]float64
+	// CentralWeights[alt]: rata-rata vektor bobot pada sampel yang menempatkan alternatif di peringkat 1
+	CentralWeights map[uint]map[uint]float64
+}
+
+// smaaSample is the outcome of one sample: the rank of every alternative and the weights used
+type smaaSample struct {
+	ranks   []int
+	weights []float64
+}
+
+// RunSMAA samples criteria weights (and optionally perturbs the scores), ranks every DM with the method,
+// aggregates the rankings and counts how often each alternative lands on each rank
+func RunSMAA(cfg SMAAConfig) (*SMAAResult, error) {
+	if cfg.Samples <= 0 || len(cfg.Criteria) == 0 || len(cfg.Alternatives) == 0 || len(cfg.ScoreSets) == 0 {
+		return nil, errors.New("SMAA: data tidak lengkap")
+	}
+	if len(cfg.ScoreSets) != len(cfg.DMIDs) || len(cfg.ScoreSets) != len(cfg.DMWeights) {
+		return nil, errors.New("SMAA: data DM tidak lengkap")
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > cfg.Samples {
+		workers = cfg.Samples
+	}
+
+	samples := make([]smaaSample, cfg.Samples)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < cfg.Samples; i += workers {
+				sample, err := runSMAASample(&cfg, i)
+				if err != nil {
+					errs[w] = err
+					return
+				}
+				samples[i] = sample
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Digabung berurutan agar hasil identik untuk jumlah worker berapa pun
+	n := len(cfg.Alternatives)
+	result := &SMAAResult{
+		Samples:           cfg.Samples,
+		Seed:              cfg.Seed,
+		RankAcceptability: make(map[uint][]float64),
+		CentralWeights:    make(map[uint]map[uint]float64),
+	}
+	firstCounts := make([]float64, n)
+	weightSums := make([][]float64, n)
+	for k := range weightSums {
+		weightSums[k] = make([]float64, len(cfg.Criteria))
+	}
+	for k, a := range cfg.Alternatives {
+		result.RankAcceptability[a.AlternativeID] = make([]float64, n)
+		for _, sample := range samples {
+			rank := sample.ranks[k]
+			if rank >= 1 && rank <= n {
+				result.RankAcceptability[a.AlternativeID][rank-1]++
+			}
+			if rank == 1 {
+				firstCounts[k]++
+				for j, w := range sample.weights {
+					weightSums[k][j] += w
+				}
+			}
+		}
+		for r := range result.RankAcceptability[a.AlternativeID] {
+			result.RankAcceptability[a.AlternativeID][r] /= float64(cfg.Samples)
+		}
+		if firstCounts[k] > 0 {
+			central := make(map[uint]float64)
+			for j, c := range cfg.Criteria {
+				central[c.CriteriaID] = weightSums[k][j] / firstCounts[k]
+			}
+			result.CentralWeights[a.AlternativeID] = central
+		}
+	}
+	return result, nil
+}
+
+func runSMAASample(cfg *SMAAConfig, index int) (smaaSample, error) {
+	rng := rand.New(rand.NewPCG(cfg.Seed, uint64(index)))
+
+	weightVector, err := sampleWeights(rng, cfg.Criteria, cfg.Bounds)
+	if err != nil {
+		return smaaSample{}, err
+	}
+	weights := make(map[uint]float64)
+	for j, c := range cfg.Criteria {
+		weights[c.CriteriaID] = weightVector[j]
+	}
+
+	var dmRankings []SingleDMRanking
+	for k, scores := range cfg.ScoreSets {
+		if cfg.ScorePerturbation > 0 {
+			scores = perturbScores(rng, scores, cfg.ScorePerturbation)
+		}
+		ranks, err := cfg.Method.CalculateRanking(scores, cfg.Criteria, cfg.Alternatives, weights, cfg.Options)
+		if err != nil {
+			return smaaSample{}, fmt.Errorf("SMAA sampel %d, DM %d: %v", index, cfg.DMIDs[k], err)
+		}
+		dmRanking := SingleDMRanking{DMID: cfg.DMIDs[k], DMWeight: cfg.DMWeights[k]}
+		for _, r := range ranks {
+			dmRanking.RankedList = append(dmRanking.RankedList, AlternativeRank{
+				AlternativeID: r.AlternativeID,
+				Rank:          r.Rank,
+				Score:         r.FinalScore,
+				Tied:          r.Tied,
+			})
+		}
+		dmRankings = append(dmRankings, dmRanking)
+	}
+
+	aggregation, err := cfg.Aggregator.Aggregate(dmRankings, cfg.Options.Ties)
+	if err != nil {
+		return smaaSample{}, err
+	}
+	rankByID := make(map[uint]int)
+	for _, r := range aggregation.Ranks {
+		rankByID[r.AlternativeID] = r.Rank
+	}
+	sample := smaaSample{ranks: make([]int, len(cfg.Alternatives)), weights: weightVector}
+	for k, a := range cfg.Alternatives {
+		sample.ranks[k] = rankByID[a.AlternativeID]
+	}
+	return sample, nil
+}
+
+// sampleWeights draws a weight vector uniformly from the simplex and rejects it until it lies within the bounds
+func sampleWeights(rng *rand.Rand, criteria []models.Criteria, bounds map[uint]WeightBound) ([]float64, error) {
+	weights := make([]float64, len(criteria))
+	for attempt := 0; attempt < smaaMaxAttempts; attempt++ {
+		// Eksponensial yang dinormalisasi = distribusi seragam pada simplex
+		sum := 0.0
+		for j := range weights {
+			weights[j] = -math.Log(1 - rng.Float64())
+			sum += weights[j]
+		}
+		inBounds := true
+		for j, c := range criteria {
+			weights[j] /= sum
+			if b, ok := bounds[c.CriteriaID]; ok && (weights[j] < b.Min || weights[j] > b.Max) {
+				inBounds = false
+			}
+		}
+		if inBounds {
+			return weights, nil
+		}
+	}
+	return nil, errors.New("SMAA: batas bobot terlalu sempit untuk diambil sampelnya")
+}
+
+// perturbScores multiplies every score with a uniform factor in [1-p, 1+p]
+func perturbScores(rng *rand.Rand, scores []models.DMInputScore, p float64) []models.DMInputScore {
+	perturbed := make([]models.DMInputScore, len(scores))
+	for i, s := range scores {
+		s.ScoreValue *= 1 + p*(2*rng.Float64()-1)
+		perturbed[i] = s
+	}
+	return perturbed
+}
@@ -0,0 +1,83 @@
+package calculations
+
+import (
+	"reflect"
+	"services/internal/models"
+	"testing"
+)
+
+// smaaTestConfig is a small two-DM project ranked with TOPSIS and aggregated with Borda
+func smaaTestConfig(seed uint64, workers int) SMAAConfig {
+	criteria := []models.Criteria{
+		{CriteriaID: 1, Type: "benefit"},
+		{CriteriaID: 2, Type: "benefit"},
+		{CriteriaID: 3, Type: "cost"},
+	}
+	alternatives := []models.Alternative{{AlternativeID: 1}, {AlternativeID: 2}, {AlternativeID: 3}, {AlternativeID: 4}}
+	grid := func(values [4][3]float64) []models.DMInputScore {
+		var scores []models.DMInputScore
+		for a, row := range values {
+			for c, v := range row {
+				scores = append(scores, models.DMInputScore{AlternativeID: uint(a + 1), CriteriaID: uint(c + 1), ScoreValue: v})
+			}
+		}
+		return scores
+	}
+
+	return SMAAConfig{
+		Samples:      500,
+		Seed:         seed,
+		Workers:      workers,
+		Criteria:     criteria,
+		Alternatives: alternatives,
+		ScoreSets: [][]models.DMInputScore{
+			grid([4][3]float64{{7, 5, 3}, {6, 8, 4}, {9, 4, 6}, {5, 6, 2}}),
+			grid([4][3]float64{{8, 6, 4}, {5, 7, 3}, {7, 5, 5}, {6, 8, 3}}),
+		},
+		DMIDs:             []uint{1, 2},
+		DMWeights:         []float64{1, 2},
+		Bounds:            map[uint]WeightBound{1: {Min: 0.1, Max: 0.7}},
+		ScorePerturbation: 0.1,
+		Method:            NewTOPSISCalculator(),
+		Aggregator:        NewBordaCalculator(),
+	}
+}
+
+func TestRunSMAAIsReproducible(t *testing.T) {
+	reference, err := RunSMAA(smaaTestConfig(42, 1))
+	if err != nil {
+		t.Fatalf("RunSMAA: %v", err)
+	}
+
+	for _, workers := range []int{1, 2, 3, 8} {
+		result, err := RunSMAA(smaaTestConfig(42, workers))
+		if err != nil {
+			t.Fatalf("RunSMAA with %d workers: %v", workers, err)
+		}
+		if !reflect.DeepEqual(result.RankAcceptability, reference.RankAcceptability) {
+			t.Errorf("%d workers: rank acceptability %v, want %v", workers, result.RankAcceptability, reference.RankAcceptability)
+		}
+		if !reflect.DeepEqual(result.CentralWeights, reference.CentralWeights) {
+			t.Errorf("%d workers: central weights %v, want %v", workers, result.CentralWeights, reference.CentralWeights)
+		}
+	}
+
+	// Setiap baris acceptability adalah distribusi peringkat
+	for id, shares := range reference.RankAcceptability {
+		sum := 0.0
+		for _, share := range shares {
+			sum += share
+		}
+		if sum < 1-scoreEpsilon || sum > 1+scoreEpsilon {
+			t.Errorf("acceptability of alternative %d sums to %g, want 1", id, sum)
+		}
+	}
+
+	other, err := RunSMAA(smaaTestConfig(43, 4))
+	if err != nil {
+		t.Fatalf("RunSMAA: %v", err)
+	}
+	if reflect.DeepEqual(other.RankAcceptability, reference.RankAcceptability) {
+		t.Error("a different seed gave identical acceptability indices")
+	}
+}
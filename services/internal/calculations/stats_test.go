@@ -0,0 +1,43 @@
+package calculations
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		mean   float64
+		stdDev float64
+		median float64
+	}{
+		{name: "empty"},
+		{name: "single", values: []float64{4}, mean: 4, median: 4},
+		{name: "odd count unsorted", values: []float64{5, 1, 3}, mean: 3, stdDev: math.Sqrt(8.0 / 3), median: 3},
+		{name: "even count unsorted", values: []float64{4, 1, 3, 2}, mean: 2.5, stdDev: math.Sqrt(1.25), median: 2.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]float64(nil), tt.values...)
+
+			if got := Mean(tt.values); math.Abs(got-tt.mean) > scoreEpsilon {
+				t.Errorf("Mean = %g, want %g", got, tt.mean)
+			}
+			mean, stdDev := MeanStdDev(tt.values)
+			if math.Abs(mean-tt.mean) > scoreEpsilon || math.Abs(stdDev-tt.stdDev) > scoreEpsilon {
+				t.Errorf("MeanStdDev = %g, %g, want %g, %g", mean, stdDev, tt.mean, tt.stdDev)
+			}
+			if got := Median(tt.values); math.Abs(got-tt.median) > scoreEpsilon {
+				t.Errorf("Median = %g, want %g", got, tt.median)
+			}
+			for i := range original {
+				if tt.values[i] != original[i] {
+					t.Fatalf("input was modified: %v, want %v", tt.values, original)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package calculations
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Tie policies: how alternatives with equal scores are ranked
+const (
+	TiePolicyShared     = "SHARED"     // standard competition ranking (1, 1, 3)
+	TiePolicyFractional = "FRACTIONAL" // shared rank; Borda splits the points of the tied positions
+	TiePolicyTieBreak   = "TIEBREAK"   // distinct ranks decided by TieBreakRule
+)
+
+// Tie-break rules used with TiePolicyTieBreak
+const (
+	TieBreakAlternativeID = "ALTERNATIVE_ID" // lower alternative ID first
+	TieBreakFirstPlaces   = "FIRST_PLACES"   // more (weighted) first places among the DMs first, then alternative ID
+)
+
+// scoreEpsilon absorbs floating point noise when comparing scores
+const scoreEpsilon = 1e-9
+
+// TieOptions selects the tie handling; the zero value means SHARED
+type TieOptions struct {
+	Policy    string
+	BreakRule string
+}
+
+func (t TieOptions) validate() error {
+	switch t.Policy {
+	case "", TiePolicyShared, TiePolicyFractional, TiePolicyTieBreak:
+	default:
+		return fmt.Errorf("tie policy %s is not supported", t.Policy)
+	}
+	switch t.BreakRule {
+	case "", TieBreakAlternativeID, TieBreakFirstPlaces:
+	default:
+		return fmt.Errorf("tie-break rule %s is not supported", t.BreakRule)
+	}
+	return nil
+}
+
+// compareScores returns 1 when a is better, -1 when b is better and 0 on a tie
+func compareScores(a, b float64) int {
+	switch {
+	case math.Abs(a-b) <= scoreEpsilon:
+		return 0
+	case a > b:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// rankOrder sorts the alternatives best-first and assigns ranks according to the tie policy.
+// compare returns >0 when a is better than b and 0 when they are tied. Ties are always flagged,
+// even when TIEBREAK gives them distinct ranks. firstPlaces is only read by the FIRST_PLACES rule.
+func rankOrder(ids []uint, compare func(a, b uint) int, ties TieOptions, firstPlaces map[uint]float64) ([]uint, map[uint]int, map[uint]bool, error) {
+	if err := ties.validate(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	order := append([]uint(nil), ids...)
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if c := compare(a, b); c != 0 {
+			return c > 0
+		}
+		if ties.Policy == TiePolicyTieBreak && ties.BreakRule == TieBreakFirstPlaces {
+			if c := compareScores(firstPlaces[a], firstPlaces[b]); c != 0 {
+				return c > 0
+			}
+		}
+		return a < b
+	})
+
+	ranks := make(map[uint]int)
+	tied := make(map[uint]bool)
+	groupStart := 0
+	for i, id := range order {
+		if i > 0 && compare(order[groupStart], id) != 0 {
+			groupStart = i
+		}
+		if i > groupStart {
+			tied[id] = true
+			tied[order[groupStart]] = true
+		}
+		if ties.Policy == TiePolicyTieBreak {
+			ranks[id] = i + 1
+		} else {
+			ranks[id] = groupStart + 1
+		}
+	}
+	return order, ranks, tied, nil
+}
+
+// rankMethodResults orders the scores of an individual decision method and assigns tie-aware ranks
+func rankMethodResults(results []TOPSISRank, ties TieOptions) ([]TOPSISRank, error) {
+	scores := make(map[uint]float64)
+	ids := make([]uint, 0, len(results))
+	for _, r := range results {
+		scores[r.AlternativeID] = r.FinalScore
+		ids = append(ids, r.AlternativeID)
+	}
+
+	compare := func(a, b uint) int { return compareScores(scores[a], scores[b]) }
+	order, ranks, tied, err := rankOrder(ids, compare, ties, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([]TOPSISRank, 0, len(order))
+	for _, id := range order {
+		ranked = append(ranked, TOPSISRank{
+			AlternativeID: id,
+			FinalScore:    scores[id],
+			Rank:          ranks[id],
+			Tied:          tied[id],
+		})
+	}
+	return ranked, nil
+}
+
+// firstPlaceVotes counts the weighted DMs that put each alternative at rank 1
+func firstPlaceVotes(dmRankings []SingleDMRanking) map[uint]float64 {
+	votes := make(map[uint]float64)
+	for _, dm := range dmRankings {
+		for _, r := range dm.RankedList {
+			if r.Rank == 1 {
+				votes[r.AlternativeID] += dmWeightOrDefault(dm.DMWeight)
+			}
+		}
+	}
+	return votes
+}
@@ -1,28 +1,41 @@
-
-
 package calculations
 
 import (
 	"errors"
-	"log"
+	"fmt"
 	"math"
 	"services/internal/models"
-	"sort"
 )
 
 type TOPSISRank struct {
 	AlternativeID uint
 	FinalScore    float64
-	Rank          int // Ranking per DM (1,2,3,...)
+	Rank          int  // Ranking per DM (1,2,3,...)
+	Tied          bool // Skor sama dengan alternatif lain
+}
+
+// TOPSISTrace holds the intermediate matrices of one TOPSIS run, keyed by alternative and criteria ID
+type TOPSISTrace struct {
+	Normalization    string                    `json:"normalization"`
+	DecisionMatrix   map[uint]map[uint]float64 `json:"decision_matrix"`
+	Normalized       map[uint]map[uint]float64 `json:"normalized_matrix"`
+	Weighted         map[uint]map[uint]float64 `json:"weighted_matrix"`
+	IdealPositive    map[uint]float64          `json:"ideal_positive"`
+	IdealNegative    map[uint]float64          `json:"ideal_negative"`
+	DistancePositive map[uint]float64          `json:"distance_positive"`
+	DistanceNegative map[uint]float64          `json:"distance_negative"`
+	Closeness        map[uint]float64          `json:"closeness"`
 }
 
 type TOPSISCalculator interface {
-	CalculateRanking(
+	DecisionMethod
+	CalculateWithTrace(
 		scores []models.DMInputScore,
 		criteria []models.Criteria,
 		alternatives []models.Alternative,
 		weights map[uint]float64,
-	) ([]TOPSISRank, error)
+		options RankingOptions,
+	) ([]TOPSISRank, *TOPSISTrace, error)
 }
 
 type topsisCalculator struct{}
@@ -31,15 +44,32 @@ func NewTOPSISCalculator() TOPSISCalculator {
 	return &topsisCalculator{}
 }
 
+func (calc *topsisCalculator) Name() string {
+	return MethodTOPSIS
+}
+
 func (calc *topsisCalculator) CalculateRanking(
 	scores []models.DMInputScore,
 	criteria []models.Criteria,
 	alternatives []models.Alternative,
 	weights map[uint]float64,
+	options RankingOptions,
 ) ([]TOPSISRank, error) {
+	results, _, err := calc.CalculateWithTrace(scores, criteria, alternatives, weights, options)
+	return results, err
+}
+
+// CalculateWithTrace ranks like CalculateRanking and also returns every intermediate matrix
+func (calc *topsisCalculator) CalculateWithTrace(
+	scores []models.DMInputScore,
+	criteria []models.Criteria,
+	alternatives []models.Alternative,
+	weights map[uint]float64,
+	options RankingOptions,
+) ([]TOPSISRank, *TOPSISTrace, error) {
 
 	if len(criteria) == 0 || len(alternatives) == 0 || len(scores) == 0 {
-		return nil, errors.New("TOPSIS: data tidak lengkap")
+		return nil, nil, errors.New("TOPSIS: data tidak lengkap")
 	}
 
 	// 1. Build matrices
@@ -58,34 +88,32 @@ func (calc *topsisCalculator) CalculateRanking(
 		}
 	}
 
-	// 2. Normalization (R matrix) - SESUAI EXCEL
-	normFactors := make(map[uint]float64)
+	// 2. Normalization (R matrix) - default VECTOR, SESUAI EXCEL
+	R := make(map[uint]map[uint]float64)
+	for _, a := range alternatives {
+		R[a.AlternativeID] = make(map[uint]float64)
+	}
 	for _, c := range criteria {
-		sumOfSquares := 0.0
-		for _, a := range alternatives {
-			score := scoreMatrix[a.AlternativeID][c.CriteriaID]
-			sumOfSquares += (score * score)
+		column := make([]float64, len(alternatives))
+		for i, a := range alternatives {
+			column[i] = scoreMatrix[a.AlternativeID][c.CriteriaID]
+		}
+		r, err := NormalizeColumn(options.Normalization, column)
+		if err != nil {
+			return nil, nil, fmt.Errorf("TOPSIS: %v", err)
+		}
+		for i, a := range alternatives {
+			R[a.AlternativeID][c.CriteriaID] = r[i]
 		}
-		normFactors[c.CriteriaID] = math.Sqrt(sumOfSquares)
 	}
 
-	// R: Normalized matrix
-	R := make(map[uint]map[uint]float64)
 	// Y: Weighted normalized matrix
 	Y := make(map[uint]map[uint]float64)
 
 	for _, a := range alternatives {
-		R[a.AlternativeID] = make(map[uint]float64)
 		Y[a.AlternativeID] = make(map[uint]float64)
 		for _, c := range criteria {
-			score := scoreMatrix[a.AlternativeID][c.CriteriaID]
-			factor := normFactors[c.CriteriaID]
-
-			var r_ij float64
-			if factor != 0 {
-				r_ij = score / factor
-			}
-			R[a.AlternativeID][c.CriteriaID] = r_ij
+			r_ij := R[a.AlternativeID][c.CriteriaID]
 
 			// Weighted: y_ij = r_ij * w_j
 			w_j := weights[c.CriteriaID]
@@ -149,6 +177,7 @@ func (calc *topsisCalculator) CalculateRanking(
 
 	// 5. Calculate final score C_i
 	var results []TOPSISRank
+	closeness := make(map[uint]float64)
 	for _, a := range alternatives {
 		dPlus := D_plus[a.AlternativeID]
 		dMinus := D_minus[a.AlternativeID]
@@ -157,6 +186,7 @@ func (calc *topsisCalculator) CalculateRanking(
 		if (dMinus + dPlus) != 0 {
 			C_i = dMinus / (dMinus + dPlus)
 		}
+		closeness[a.AlternativeID] = C_i
 
 		results = append(results, TOPSISRank{
 			AlternativeID: a.AlternativeID,
@@ -164,16 +194,26 @@ func (calc *topsisCalculator) CalculateRanking(
 		})
 	}
 
-	// Sort by FinalScore descending
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].FinalScore > results[j].FinalScore
-	})
-
-	// Log results
-	log.Println("=== TOPSIS FINAL RANKING ===")
-	for i, r := range results {
-		log.Printf("Rank %d: Alt ID %d, Score: %.4f", i+1, r.AlternativeID, r.FinalScore)
+	// Sort by FinalScore descending with tie-aware ranks
+	results, err := rankMethodResults(results, options.Ties)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return results, nil
-}
\ No newline at end of file
+	normalization := options.Normalization
+	if normalization == "" {
+		normalization = NormalizationVector
+	}
+	trace := &TOPSISTrace{
+		Normalization:    normalization,
+		DecisionMatrix:   scoreMatrix,
+		Normalized:       R,
+		Weighted:         Y,
+		IdealPositive:    A_plus,
+		IdealNegative:    A_minus,
+		DistancePositive: D_plus,
+		DistanceNegative: D_minus,
+		Closeness:        closeness,
+	}
+	return results, trace, nil
+}
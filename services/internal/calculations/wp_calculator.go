@@ -0,0 +1,137 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"services/internal/models"
+)
+
+// WPResult holds the S (vector S) and V (vector V) values of the Weighted Product method
+type WPResult struct {
+	// Exponents adalah bobot ternormalisasi per kriteria; negatif untuk kriteria cost
+	Exponents map[uint]float64 `json:"exponents"`
+	S         map[uint]float64 `json:"s"`
+	V         map[uint]float64 `json:"v"`
+	// Ranks sudah tercantum di trace DM, jadi tidak diulang di JSON
+	Ranks []TOPSISRank `json:"-"`
+}
+
+type WPCalculator interface {
+	DecisionMethod
+	CalculateVectors(
+		scores []models.DMInputScore,
+		criteria []models.Criteria,
+		alternatives []models.Alternative,
+		weights map[uint]float64,
+		options RankingOptions,
+	) (*WPResult, error)
+}
+
+type wpCalculator struct{}
+
+func NewWPCalculator() WPCalculator {
+	return &wpCalculator{}
+}
+
+func (calc *wpCalculator) Name() string {
+	return MethodWP
+}
+
+func (calc *wpCalculator) CalculateRanking(
+	scores []models.DMInputScore,
+	criteria []models.Criteria,
+	alternatives []models.Alternative,
+	weights map[uint]float64,
+	options RankingOptions,
+) ([]TOPSISRank, error) {
+	result, err := calc.CalculateVectors(scores, criteria, alternatives, weights, options)
+	if err != nil {
+		return nil, err
+	}
+	return result.Ranks, nil
+}
+
+func (calc *wpCalculator) CalculateVectors(
+	scores []models.DMInputScore,
+	criteria []models.Criteria,
+	alternatives []models.Alternative,
+	weights map[uint]float64,
+	options RankingOptions,
+) (*WPResult, error) {
+
+	if len(criteria) == 0 || len(alternatives) == 0 || len(scores) == 0 {
+		return nil, errors.New("WP: data tidak lengkap")
+	}
+
+	// 1. Build decision matrix
+	scoreMatrix := make(map[uint]map[uint]float64)
+	for _, a := range alternatives {
+		scoreMatrix[a.AlternativeID] = make(map[uint]float64)
+	}
+	for _, s := range scores {
+		if _, ok := scoreMatrix[s.AlternativeID]; ok {
+			scoreMatrix[s.AlternativeID][s.CriteriaID] = s.ScoreValue
+		}
+	}
+
+	// 2. Normalize weights so that Σw = 1; cost criteria get a negative exponent
+	totalWeight := 0.0
+	for _, c := range criteria {
+		totalWeight += weights[c.CriteriaID]
+	}
+	if totalWeight == 0 {
+		return nil, errors.New("WP: total bobot kriteria tidak boleh 0")
+	}
+
+	exponents := make(map[uint]float64)
+	for _, c := range criteria {
+		w_j := weights[c.CriteriaID] / totalWeight
+		if c.Type == "cost" {
+			w_j = -w_j
+		}
+		exponents[c.CriteriaID] = w_j
+	}
+
+	// 3. Vector S: S_i = Π x_ij ^ w_j
+	result := &WPResult{
+		Exponents: exponents,
+		S:         make(map[uint]float64),
+		V:         make(map[uint]float64),
+	}
+	sumS := 0.0
+	for _, a := range alternatives {
+		S_i := 1.0
+		for _, c := range criteria {
+			x := scoreMatrix[a.AlternativeID][c.CriteriaID]
+			if x <= 0 {
+				return nil, fmt.Errorf("WP: skor kriteria %s harus > 0", c.Name)
+			}
+			S_i *= math.Pow(x, exponents[c.CriteriaID])
+		}
+		result.S[a.AlternativeID] = S_i
+		sumS += S_i
+	}
+
+	// 4. Vector V: V_i = S_i / Σ S
+	for _, a := range alternatives {
+		V_i := 0.0
+		if sumS != 0 {
+			V_i = result.S[a.AlternativeID] / sumS
+		}
+		result.V[a.AlternativeID] = V_i
+		result.Ranks = append(result.Ranks, TOPSISRank{
+			AlternativeID: a.AlternativeID,
+			FinalScore:    V_i,
+		})
+	}
+
+	// Sort by FinalScore descending with tie-aware ranks
+	ranks, err := rankMethodResults(result.Ranks, options.Ties)
+	if err != nil {
+		return nil, err
+	}
+	result.Ranks = ranks
+
+	return result, nil
+}
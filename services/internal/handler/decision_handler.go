@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"io"
 	"net/http"
 	"services/internal/models"
 	"services/internal/service"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,6 +13,15 @@ import (
 type DecisionHandler interface {
 	TriggerCalculation(c *gin.Context)
 	GetResults(c *gin.Context)
+	GetRuns(c *gin.Context)
+	GetRunSnapshot(c *gin.Context)
+	ReplayRun(c *gin.Context)
+	GetConsensus(c *gin.Context)
+	GetScoreCompleteness(c *gin.Context)
+	AnalyzeSensitivity(c *gin.Context)
+	AnalyzeSMAA(c *gin.Context)
+	Simulate(c *gin.Context)
+	GetTrace(c *gin.Context)
 }
 
 type decisionHandler struct {
@@ -27,12 +38,12 @@ func (calc *decisionHandler) TriggerCalculation(c *gin.Context) {
 		return
 	}
 
-	_, companyID, role, err := extractUserData(c)
+	userID, companyID, role, err := extractUserData(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
 		return
 	}
-	err = calc.decisonService.CalculateResults(projectID, companyID, role)
+	aggregation, run, err := calc.decisonService.CalculateResults(projectID, companyID, userID, role)
 	if err != nil {
 		if err.Error() == "only admins can trigger calculation" {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
@@ -46,7 +57,7 @@ func (calc *decisionHandler) TriggerCalculation(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Calculation completed successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Calculation completed successfully", "run_id": run.RunID, "aggregation": aggregation})
 }
 
 func (h *decisionHandler) GetResults(c *gin.Context) {
@@ -60,9 +71,18 @@ func (h *decisionHandler) GetResults(c *gin.Context) {
 		return
 	}
 
-	results, err := h.decisonService.GetResults(projectID, companyID)
+	round, err := getRoundFromQuery(c)
 	if err != nil {
-		if err.Error() == "project not found or user does not have access" {
+		return
+	}
+	runID, err := getRunFromQuery(c)
+	if err != nil {
+		return
+	}
+
+	results, err := h.decisonService.GetResults(projectID, companyID, round, runID)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" || err.Error() == "calculation run not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
@@ -80,8 +100,316 @@ func (h *decisionHandler) GetResults(c *gin.Context) {
 			ProjectDMID:   r.ProjectDMID,
 			FinalScore:    r.FinalScore,
 			Rank:          r.Rank,
+			RoundNumber:   r.RoundNumber,
+			RunID:         r.RunID,
+			Normalization: r.Normalization,
+			IsTied:        r.IsTied,
 		})
 	}
 
 	c.JSON(http.StatusOK, resultDTOs)
 }
+
+func (h *decisionHandler) GetRuns(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	runs, err := h.decisonService.GetRuns(projectID, companyID)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+func (h *decisionHandler) GetRunSnapshot(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	runID, err := getIDFromParam(c, "runID")
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	snapshot, err := h.decisonService.GetRunSnapshot(projectID, companyID, runID)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" ||
+			err.Error() == "calculation run not found" ||
+			err.Error() == "calculation run has no input snapshot" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot)
+}
+
+func (h *decisionHandler) ReplayRun(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	runID, err := getIDFromParam(c, "runID")
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	replay, err := h.decisonService.ReplayRun(projectID, companyID, runID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "unsupported input snapshot version") {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "project not found or user does not have access" ||
+			err.Error() == "calculation run not found" ||
+			err.Error() == "calculation run has no input snapshot" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, replay)
+}
+
+func (h *decisionHandler) GetConsensus(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	round, err := getRoundFromQuery(c)
+	if err != nil {
+		return
+	}
+
+	consensus, err := h.decisonService.GetConsensus(projectID, companyID, round)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" || err.Error() == "no calculation results found for this project" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "consensus requires rankings from at least two decision makers" ||
+			err.Error() == "consensus is not available in AIJ mode because decision makers have no individual rankings" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, consensus)
+}
+
+func (h *decisionHandler) GetScoreCompleteness(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	round, err := getRoundFromQuery(c)
+	if err != nil {
+		return
+	}
+
+	completeness, err := h.decisonService.GetScoreCompleteness(projectID, companyID, round)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, completeness)
+}
+
+func (h *decisionHandler) AnalyzeSensitivity(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	var input models.SensitivityInput
+	if err := c.ShouldBindJSON(&input); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sensitivity, err := h.decisonService.AnalyzeSensitivity(projectID, companyID, input)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "min_weight must be lower than max_weight" ||
+			err.Error() == "sensitivity analysis needs at least two leaf criteria" ||
+			err.Error() == "criteria_ids must reference leaf criteria of this project" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sensitivity)
+}
+
+func (h *decisionHandler) AnalyzeSMAA(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	var input models.SMAAInput
+	if err := c.ShouldBindJSON(&input); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	smaa, err := h.decisonService.AnalyzeSMAA(projectID, companyID, input)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "weight bounds must reference leaf criteria of this project" ||
+			err.Error() == "weight bound min must not exceed max" ||
+			err.Error() == "weight bounds cannot sum to 1" ||
+			err.Error() == "SMAA: batas bobot terlalu sempit untuk diambil sampelnya" ||
+			err.Error() == "SMAA needs complete scores; impute them instead of excluding decision makers" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, smaa)
+}
+
+func (h *decisionHandler) Simulate(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, role, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	var input models.SimulateInput
+	if err := c.ShouldBindJSON(&input); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	simulation, err := h.decisonService.Simulate(projectID, companyID, role, input)
+	if err != nil {
+		if err.Error() == "only admins can run simulations" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "project not found or user does not have access" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "criteria weight override references unknown criteria" ||
+			err.Error() == "criteria weight overrides only apply to projects weighted by the admin" ||
+			err.Error() == "group weight override references unknown decision maker" ||
+			err.Error() == "score override references unknown decision maker" ||
+			err.Error() == "score override must reference an alternative and a leaf criteria of this project" ||
+			strings.HasPrefix(err.Error(), "invalid score") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, simulation)
+}
+
+func (h *decisionHandler) GetTrace(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	round, err := getRoundFromQuery(c)
+	if err != nil {
+		return
+	}
+
+	runID, err := getRunFromQuery(c)
+	if err != nil {
+		return
+	}
+
+	trace, err := h.decisonService.GetTrace(projectID, companyID, round, runID)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" ||
+			err.Error() == "calculation run not found" ||
+			err.Error() == "no calculation trace found for this project" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, trace)
+}
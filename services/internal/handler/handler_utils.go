@@ -43,3 +43,31 @@ func getIDFromParam(c *gin.Context, paramName string) (uint, error) {
 	}
 	return uint(id), nil
 }
+
+// getRoundFromQuery reads the optional ?round= query; 0 means the current round
+func getRoundFromQuery(c *gin.Context) (int, error) {
+	roundStr := c.Query("round")
+	if roundStr == "" {
+		return 0, nil
+	}
+	round, err := strconv.Atoi(roundStr)
+	if err != nil || round < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid round format"})
+		return 0, errors.New("invalid round")
+	}
+	return round, nil
+}
+
+// getRunFromQuery reads the optional ?run= query; empty or "latest" gives 0, the latest run
+func getRunFromQuery(c *gin.Context) (uint, error) {
+	runStr := c.Query("run")
+	if runStr == "" || runStr == "latest" {
+		return 0, nil
+	}
+	runID, err := strconv.ParseUint(runStr, 10, 32)
+	if err != nil || runID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid run format"})
+		return 0, errors.New("invalid run")
+	}
+	return uint(runID), nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"services/internal/models"
 	"services/internal/service"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -49,6 +50,10 @@ func (h *inputDirectWeightHandler) SubmitDirectWeights(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
+		if strings.HasPrefix(err.Error(), "invalid direct weights") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"services/internal/models"
+	"services/internal/service"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InputPairwiseHandler interface {
+	SubmitPairwise(c *gin.Context)
+	GetPairwise(c *gin.Context)
+}
+
+type inputPairwiseHandler struct {
+	pairwiseService service.InputPairwiseService
+}
+
+func NewInputPairwiseHandler(pairwiseService service.InputPairwiseService) InputPairwiseHandler {
+	return &inputPairwiseHandler{pairwiseService: pairwiseService}
+}
+
+func (h *inputPairwiseHandler) SubmitPairwise(c *gin.Context) {
+	var input models.SumbitPairwiseInput
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+
+	dmUserID, _, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	result, err := h.pairwiseService.SubmitPairwise(input, projectID, dmUserID)
+	if err != nil {
+		errMsg := err.Error()
+		switch {
+		case errMsg == "user is not an assigned decision maker for this project":
+			c.JSON(http.StatusForbidden, gin.H{"error": errMsg})
+		case result != nil:
+			// Matriks tidak konsisten: kembalikan CR agar DM dapat merevisi penilaiannya
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": errMsg, "result": result})
+		case errMsg == "criteria does not belong to this project",
+			errMsg == "compared criteria must share the given parent criteria",
+			errMsg == "pairwise comparison value must be between 1/9 and 9",
+			strings.HasPrefix(errMsg, "invalid pairwise comparisons"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": errMsg})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *inputPairwiseHandler) GetPairwise(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+
+	dmUserID, _, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	result, err := h.pairwiseService.GetPairwise(projectID, dmUserID, c.Query("method"))
+	if err != nil {
+		if err.Error() == "user is not an assigned decision maker for this project" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.HasPrefix(err.Error(), "invalid pairwise comparisons") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"services/internal/models"
 	"services/internal/service"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -47,6 +48,11 @@ func (h *inputScoreHandler) SubmitScores(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "criteria does not belong to this project" || err.Error() == "scores can only be submitted for leaf criteria" ||
+			strings.HasPrefix(err.Error(), "invalid score") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -79,6 +85,11 @@ func (h *inputScoreHandler) SubmitScore(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "criteria does not belong to this project" || err.Error() == "scores can only be submitted for leaf criteria" ||
+			strings.HasPrefix(err.Error(), "invalid score") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
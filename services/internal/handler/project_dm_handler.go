@@ -67,6 +67,8 @@ func (h *projectDMHandler) AssignDM(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
 		case "this decision maker is already assigned to this project":
 			c.JSON(http.StatusConflict, gin.H{"error": errMsg}) // 409 Conflict
+		case "unsupported decision method":
+			c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
 		default:
 
 			c.JSON(http.StatusInternalServerError, gin.H{"error": errMsg})
@@ -164,6 +166,8 @@ func (h *projectDMHandler) UpdateAssignment(c *gin.Context) {
 		switch errMsg {
 		case "only admins can update decision maker assignments":
 			c.JSON(http.StatusForbidden, gin.H{"error": errMsg})
+		case "unsupported decision method":
+			c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
 		case "assignment not found":
 			c.JSON(http.StatusNotFound, gin.H{"error": errMsg})
 		case "project not found or admin does not have access":
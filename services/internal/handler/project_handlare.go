@@ -50,6 +50,10 @@ func (h *projectHandler) CreateProject(c *gin.Context) {
 
 	projectDTO, err := h.projectService.CreateProject(input, userID, companyID)
 	if err != nil {
+		if err.Error() == "unknown aggregation method" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -135,6 +139,10 @@ func (h *projectHandler) UpdateProject(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Project not found or you do not have permission"})
 			return
 		}
+		if err.Error() == "unknown aggregation method" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
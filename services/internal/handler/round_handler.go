@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+	"services/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoundHandler interface {
+	GetRounds(c *gin.Context)
+	OpenNextRound(c *gin.Context)
+	GetRoundFeedback(c *gin.Context)
+}
+
+type roundHandler struct {
+	roundService service.RoundService
+}
+
+func NewRoundHandler(roundService service.RoundService) RoundHandler {
+	return &roundHandler{roundService: roundService}
+}
+
+func (h *roundHandler) GetRounds(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	rounds, err := h.roundService.GetRounds(projectID, companyID)
+	if err != nil {
+		if err.Error() == "project not found or user does not have access" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rounds)
+}
+
+func (h *roundHandler) OpenNextRound(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	_, companyID, role, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	round, err := h.roundService.OpenNextRound(projectID, companyID, role)
+	if err != nil {
+		if err.Error() == "only admins can open a new round" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "project not found or user does not have access" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "the round has already been advanced by another request" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()}) // 409 Conflict
+			return
+		}
+		if err.Error() == "calculate the current round before opening a new one" ||
+			err.Error() == "consensus threshold already reached" ||
+			err.Error() == "consensus requires rankings from at least two decision makers" ||
+			err.Error() == "consensus is not available in AIJ mode because decision makers have no individual rankings" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, round)
+}
+
+func (h *roundHandler) GetRoundFeedback(c *gin.Context) {
+	projectID, err := getProjectIDFromParam(c)
+	if err != nil {
+		return
+	}
+	dmUserID, _, _, err := extractUserData(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process user data"})
+		return
+	}
+
+	feedback, err := h.roundService.GetRoundFeedback(projectID, dmUserID)
+	if err != nil {
+		if err.Error() == "user is not an assigned decision maker for this project" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "feedback is available from the second round onwards" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feedback)
+}
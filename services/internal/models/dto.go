@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -44,24 +45,58 @@ type CreateProjectInput struct {
 	ProjectName       string `json:"project_name" binding:"required"`
 	Description       string `json:"description"`
 	AggregationMethod string `json:"aggregation_method" binding:"required"`
+	// WeightingMode: ADMIN (bobot admin), DM_DIRECT (bobot langsung tiap DM), BLEND (campuran),
+	// ENTROPY/CRITIC (bobot objektif dari skor tiap DM), ENTROPY_POOLED/CRITIC_POOLED (dari skor gabungan semua DM)
+	WeightingMode string `json:"weighting_mode" binding:"omitempty,oneof=ADMIN DM_DIRECT BLEND ENTROPY ENTROPY_POOLED CRITIC CRITIC_POOLED"`
+	// WeightBlend adalah porsi bobot admin pada mode BLEND (sisanya bobot DM)
+	WeightBlend *float64 `json:"weight_blend" binding:"omitempty,gte=0,lte=1"`
+	// Normalization untuk TOPSIS: VECTOR (default), LINEAR_MAX, MIN_MAX, LINEAR_SUM
+	Normalization string `json:"normalization" binding:"omitempty,oneof=VECTOR LINEAR_MAX MIN_MAX LINEAR_SUM"`
+	// TiePolicy: SHARED (ranking bersama, default), FRACTIONAL (poin Borda dibagi rata), TIEBREAK (dipecah dengan TieBreakRule)
+	TiePolicy    string `json:"tie_policy" binding:"omitempty,oneof=SHARED FRACTIONAL TIEBREAK"`
+	TieBreakRule string `json:"tie_break_rule" binding:"omitempty,oneof=ALTERNATIVE_ID FIRST_PLACES"`
+	// GroupMode: AIP (ranking tiap DM lalu diagregasi, default), AIJ_ARITHMETIC/AIJ_GEOMETRIC (matriks skor digabung lalu satu TOPSIS)
+	GroupMode string `json:"group_mode" binding:"omitempty,oneof=AIP AIJ_ARITHMETIC AIJ_GEOMETRIC"`
+	// ConsensusThreshold: batas Kendall's W untuk membuka putaran penilaian baru
+	ConsensusThreshold *float64 `json:"consensus_threshold" binding:"omitempty,gte=0,lte=1"`
+	// MissingScorePolicy: BLOCK (tolak perhitungan, default), IMPUTE_MEAN/IMPUTE_MEDIAN (isi dengan rata-rata/median kriteria),
+	// EXCLUDE_DM (DM tidak dihitung pada kriteria yang skornya belum lengkap)
+	MissingScorePolicy string `json:"missing_score_policy" binding:"omitempty,oneof=BLOCK IMPUTE_MEAN IMPUTE_MEDIAN EXCLUDE_DM"`
 }
 
 type UpdateProjectInput struct {
-	ProjectName       string `json:"project_name"`
-	Description       string `json:"description"`
-	Status            string `json:"status"`
-	AggregationMethod string `json:"aggregation_method"`
+	ProjectName        string   `json:"project_name"`
+	Description        string   `json:"description"`
+	Status             string   `json:"status"`
+	AggregationMethod  string   `json:"aggregation_method"`
+	WeightingMode      string   `json:"weighting_mode" binding:"omitempty,oneof=ADMIN DM_DIRECT BLEND ENTROPY ENTROPY_POOLED CRITIC CRITIC_POOLED"`
+	WeightBlend        *float64 `json:"weight_blend" binding:"omitempty,gte=0,lte=1"`
+	Normalization      string   `json:"normalization" binding:"omitempty,oneof=VECTOR LINEAR_MAX MIN_MAX LINEAR_SUM"`
+	TiePolicy          string   `json:"tie_policy" binding:"omitempty,oneof=SHARED FRACTIONAL TIEBREAK"`
+	TieBreakRule       string   `json:"tie_break_rule" binding:"omitempty,oneof=ALTERNATIVE_ID FIRST_PLACES"`
+	GroupMode          string   `json:"group_mode" binding:"omitempty,oneof=AIP AIJ_ARITHMETIC AIJ_GEOMETRIC"`
+	ConsensusThreshold *float64 `json:"consensus_threshold" binding:"omitempty,gte=0,lte=1"`
+	MissingScorePolicy string   `json:"missing_score_policy" binding:"omitempty,oneof=BLOCK IMPUTE_MEAN IMPUTE_MEDIAN EXCLUDE_DM"`
 }
 
 type ProjectDTO struct {
-	ProjectID         uint      `json:"project_id"`
-	CompanyID         uint      `json:"company_id"`
-	CreatedByAdminID  uint      `json:"created_by_admin_id"`
-	ProjectName       string    `json:"project_name"`
-	Description       string    `json:"description"`
-	Status            string    `json:"status"`
-	AggregationMethod string    `json:"aggregation_method"`
-	CrateAt           time.Time `json:"created_at"`
+	ProjectID          uint      `json:"project_id"`
+	CompanyID          uint      `json:"company_id"`
+	CreatedByAdminID   uint      `json:"created_by_admin_id"`
+	ProjectName        string    `json:"project_name"`
+	Description        string    `json:"description"`
+	Status             string    `json:"status"`
+	AggregationMethod  string    `json:"aggregation_method"`
+	WeightingMode      string    `json:"weighting_mode"`
+	WeightBlend        float64   `json:"weight_blend"`
+	Normalization      string    `json:"normalization"`
+	TiePolicy          string    `json:"tie_policy"`
+	TieBreakRule       string    `json:"tie_break_rule"`
+	GroupMode          string    `json:"group_mode"`
+	CurrentRound       int       `json:"current_round"`
+	ConsensusThreshold float64   `json:"consensus_threshold"`
+	MissingScorePolicy string    `json:"missing_score_policy"`
+	CrateAt            time.Time `json:"created_at"`
 }
 
 type CreateCriteriaInput struct {
@@ -70,6 +105,13 @@ type CreateCriteriaInput struct {
 	Type             string  `json:"type" binding:"required,oneof=benefit cost"`
 	Weight           float64 `json:"weight" binding:"required,gte=0,lte=1"`
 	ParentCriteriaID *uint   `json:"parent_criteria_id"`
+	// ScaleType: NUMERIC (default, ScaleMin/ScaleMax/ScaleStep opsional), LIKERT_5 (bilangan bulat 1–5, label opsional),
+	// ORDINAL (bilangan bulat 1..n sesuai urutan ScaleLabels)
+	ScaleType   string   `json:"scale_type" binding:"omitempty,oneof=NUMERIC LIKERT_5 ORDINAL"`
+	ScaleMin    *float64 `json:"scale_min" binding:"omitempty,gte=0"`
+	ScaleMax    *float64 `json:"scale_max" binding:"omitempty,gte=0"`
+	ScaleStep   *float64 `json:"scale_step" binding:"omitempty,gt=0"`
+	ScaleLabels []string `json:"scale_labels" binding:"omitempty,dive,required"`
 }
 
 type UpdateCriteriaInput struct {
@@ -77,6 +119,12 @@ type UpdateCriteriaInput struct {
 	Code   string  `json:"code"`
 	Type   string  `json:"type" binding:"omitempty,oneof=benefit cost"`
 	Weight float64 `json:"weight" binding:"omitempty,gte=0,lte=1"`
+	// Skala hanya diubah bila ScaleType diisi; definisi skala lama diganti seluruhnya
+	ScaleType   string   `json:"scale_type" binding:"omitempty,oneof=NUMERIC LIKERT_5 ORDINAL"`
+	ScaleMin    *float64 `json:"scale_min" binding:"omitempty,gte=0"`
+	ScaleMax    *float64 `json:"scale_max" binding:"omitempty,gte=0"`
+	ScaleStep   *float64 `json:"scale_step" binding:"omitempty,gt=0"`
+	ScaleLabels []string `json:"scale_labels" binding:"omitempty,dive,required"`
 }
 
 type CriteriaDTO struct {
@@ -87,6 +135,12 @@ type CriteriaDTO struct {
 	Code             string        `json:"code"`
 	Type             string        `json:"type"`
 	Weight           float64       `json:"weight"`
+	GlobalWeight     float64       `json:"global_weight,omitempty"`
+	ScaleType        string        `json:"scale_type"`
+	ScaleMin         *float64      `json:"scale_min,omitempty"`
+	ScaleMax         *float64      `json:"scale_max,omitempty"`
+	ScaleStep        *float64      `json:"scale_step,omitempty"`
+	ScaleLabels      []string      `json:"scale_labels,omitempty"`
 	SubCriteria      []CriteriaDTO `json:"sub_criteria,omitempty"`
 }
 
@@ -109,12 +163,12 @@ type AlternativeDTO struct {
 
 type AssignDMInput struct {
 	DMUserID    uint    `json:"dm_user_id" binding:"required"`
-	Method      string  `json:"method" binding:"required,oneof=TOPSIS"`
+	Method      string  `json:"method" binding:"required,oneof=TOPSIS SAW WP AHP"`
 	GroupWeight float64 `json:"group_weight" binding:"required,gte=0,lte=10"`
 }
 
 type UpdateProjectDMInput struct {
-	Method      string  `json:"method" binding:"required,oneof=TOPSIS"`
+	Method      string  `json:"method" binding:"required,oneof=TOPSIS SAW WP AHP"`
 	GroupWeight float64 `json:"group_weight" binding:"required,gte=0,lte=10"`
 }
 
@@ -134,8 +188,27 @@ type PairwiseInputItem struct {
 }
 
 type SumbitPairwiseInput struct {
+	Method      string              `json:"method" binding:"omitempty,oneof=EIGENVECTOR GEOMETRIC_MEAN"`
 	Comparisons []PairwiseInputItem `json:"comparisons" binding:"required,dive"`
 }
+
+// PairwiseGroupResultDTO holds the AHP priorities of one group of sibling criteria
+type PairwiseGroupResultDTO struct {
+	ParentCriteriaID *uint            `json:"parent_criteria_id"`
+	Priorities       map[uint]float64 `json:"priorities"`
+	LambdaMax        float64          `json:"lambda_max"`
+	CI               float64          `json:"ci"`
+	RI               float64          `json:"ri"`
+	CR               float64          `json:"cr"`
+	Consistent       bool             `json:"consistent"`
+}
+
+type PairwiseResultDTO struct {
+	Method      string                   `json:"method"`
+	Consistent  bool                     `json:"consistent"`
+	Comparisons []DMInputPairwise        `json:"comparisons"`
+	Groups      []PairwiseGroupResultDTO `json:"groups"`
+}
 type DirectWeightInputItem struct {
 	CriteriaID  uint    `json:"criteria_id" binding:"required"`
 	WeightValue float64 `json:"weight_value" binding:"required,gte=0,lte=1"`
@@ -163,4 +236,297 @@ type ResultRankingDTO struct {
 	ProjectDMID   *uint   `json:"project_dm_id"`
 	FinalScore    float64 `json:"final_score"`
 	Rank          int     `json:"rank"`
+	RoundNumber   int     `json:"round_number"`
+	RunID         *uint   `json:"run_id"`
+	Normalization string  `json:"normalization,omitempty"`
+	IsTied        bool    `json:"is_tied"`
+}
+
+// DMPairAgreementDTO is the agreement between the rankings of two decision makers
+type DMPairAgreementDTO struct {
+	ProjectDMIDA uint    `json:"project_dm_id_a"`
+	ProjectDMIDB uint    `json:"project_dm_id_b"`
+	SpearmanRho  float64 `json:"spearman_rho"`
+	KendallTau   float64 `json:"kendall_tau"`
+}
+
+// DMConsensusDTO compares one decision maker's ranking with the final group ranking
+type DMConsensusDTO struct {
+	ProjectDMID uint `json:"project_dm_id"`
+	// DistanceToFinal adalah jumlah pasangan alternatif yang urutannya berlawanan dengan ranking final
+	DistanceToFinal    int     `json:"distance_to_final"`
+	NormalizedDistance float64 `json:"normalized_distance"`
+	SpearmanRhoToFinal float64 `json:"spearman_rho_to_final"`
+	KendallTauToFinal  float64 `json:"kendall_tau_to_final"`
+}
+
+type ConsensusDTO struct {
+	ProjectID        uint                 `json:"project_id"`
+	RoundNumber      int                  `json:"round_number"`
+	DMCount          int                  `json:"dm_count"`
+	AlternativeCount int                  `json:"alternative_count"`
+	KendallW         float64              `json:"kendall_w"`
+	Pairs            []DMPairAgreementDTO `json:"pairs"`
+	DecisionMakers   []DMConsensusDTO     `json:"decision_makers"`
+}
+
+// RoundFeedbackScoreDTO is the anonymized group view of one cell of the score matrix
+type RoundFeedbackScoreDTO struct {
+	AlternativeID uint     `json:"alternative_id"`
+	CriteriaID    uint     `json:"criteria_id"`
+	GroupMean     float64  `json:"group_mean"`
+	GroupMedian   float64  `json:"group_median"`
+	GroupMin      float64  `json:"group_min"`
+	GroupMax      float64  `json:"group_max"`
+	GroupStdDev   float64  `json:"group_std_dev"`
+	YourScore     *float64 `json:"your_score"`
+}
+
+type RoundFeedbackWeightDTO struct {
+	CriteriaID  uint     `json:"criteria_id"`
+	GroupMean   float64  `json:"group_mean"`
+	GroupStdDev float64  `json:"group_std_dev"`
+	YourWeight  *float64 `json:"your_weight"`
+}
+
+type RoundFeedbackRankDTO struct {
+	AlternativeID uint    `json:"alternative_id"`
+	Rank          int     `json:"rank"`
+	FinalScore    float64 `json:"final_score"`
+	YourRank      *int    `json:"your_rank"`
+}
+
+// RoundFeedbackDTO is what a DM sees of the previous round before revising their inputs; it never names other DMs
+type RoundFeedbackDTO struct {
+	ProjectID     uint                     `json:"project_id"`
+	CurrentRound  int                      `json:"current_round"`
+	FeedbackRound int                      `json:"feedback_round"`
+	KendallW      *float64                 `json:"kendall_w"`
+	FinalRanking  []RoundFeedbackRankDTO   `json:"final_ranking"`
+	Scores        []RoundFeedbackScoreDTO  `json:"scores"`
+	Weights       []RoundFeedbackWeightDTO `json:"weights,omitempty"`
+}
+
+// SensitivityInput configures the weight sensitivity analysis; empty fields use the defaults
+type SensitivityInput struct {
+	// CriteriaIDs: kriteria daun yang dianalisis, kosong berarti semua kriteria daun
+	CriteriaIDs []uint   `json:"criteria_ids"`
+	TopK        int      `json:"top_k" binding:"omitempty,gte=1"`
+	Steps       int      `json:"steps" binding:"omitempty,gte=2,lte=200"`
+	MinWeight   *float64 `json:"min_weight" binding:"omitempty,gte=0,lte=1"`
+	MaxWeight   *float64 `json:"max_weight" binding:"omitempty,gte=0,lte=1"`
+}
+
+// RankReversalDTO is a weight at which the final ranking changes
+type RankReversalDTO struct {
+	Weight float64 `json:"weight"`
+	// Delta adalah selisih terhadap bobot dasar kriteria
+	Delta         float64 `json:"delta"`
+	RankingBefore []uint  `json:"ranking_before"`
+	RankingAfter  []uint  `json:"ranking_after"`
+	ChangesTopK   bool    `json:"changes_top_k"`
+}
+
+// StableIntervalDTO is a range of weights over which the top-k stays the same
+type StableIntervalDTO struct {
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+	TopK []uint  `json:"top_k"`
+}
+
+type CriterionSensitivityDTO struct {
+	CriteriaID uint    `json:"criteria_id"`
+	Name       string  `json:"name"`
+	BaseWeight float64 `json:"base_weight"`
+	// BaseInterval adalah rentang di sekitar bobot dasar tempat top-k sama dengan hasil dasar
+	BaseInterval *StableIntervalDTO `json:"base_interval"`
+	// MinChangeToFlip adalah perubahan bobot terkecil yang mengubah top-k; nil bila tidak pernah berubah
+	MinChangeToFlip *float64            `json:"min_change_to_flip"`
+	Intervals       []StableIntervalDTO `json:"intervals"`
+	Reversals       []RankReversalDTO   `json:"reversals"`
+}
+
+type SensitivityDTO struct {
+	ProjectID   uint                      `json:"project_id"`
+	RoundNumber int                       `json:"round_number"`
+	TopK        int                       `json:"top_k"`
+	MinWeight   float64                   `json:"min_weight"`
+	MaxWeight   float64                   `json:"max_weight"`
+	Steps       int                       `json:"steps"`
+	BaseRanking []uint                    `json:"base_ranking"`
+	Criteria    []CriterionSensitivityDTO `json:"criteria"`
+}
+
+type SMAAWeightBoundInput struct {
+	CriteriaID uint    `json:"criteria_id" binding:"required"`
+	Min        float64 `json:"min" binding:"gte=0,lte=1"`
+	Max        float64 `json:"max" binding:"gte=0,lte=1"`
+}
+
+// SMAAInput configures a Monte Carlo acceptability analysis; empty fields use the defaults
+type SMAAInput struct {
+	Samples int `json:"samples" binding:"omitempty,gte=1,lte=100000"`
+	// Seed: tanpa seed, seed acak dipilih dan dikembalikan agar hasil dapat diulang.
+	// Dibatasi 2^53-1 supaya tidak kehilangan presisi di JavaScript
+	Seed              *uint64                `json:"seed" binding:"omitempty,lte=9007199254740991"`
+	Workers           int                    `json:"workers" binding:"omitempty,gte=1,lte=64"`
+	WeightBounds      []SMAAWeightBoundInput `json:"weight_bounds" binding:"omitempty,dive"`
+	ScorePerturbation float64                `json:"score_perturbation" binding:"gte=0,lte=1"`
+}
+
+type SMAAAlternativeDTO struct {
+	AlternativeID uint   `json:"alternative_id"`
+	Name          string `json:"name"`
+	// RankAcceptability[r-1] adalah porsi sampel yang menempatkan alternatif pada peringkat r
+	RankAcceptability []float64 `json:"rank_acceptability"`
+	// CentralWeights: bobot rata-rata saat alternatif menjadi peringkat 1; kosong bila tidak pernah
+	CentralWeights map[uint]float64 `json:"central_weights,omitempty"`
+}
+
+type SMAADTO struct {
+	ProjectID         uint                 `json:"project_id"`
+	RoundNumber       int                  `json:"round_number"`
+	Method            string               `json:"method"`
+	Aggregation       string               `json:"aggregation"`
+	Samples           int                  `json:"samples"`
+	Seed              uint64               `json:"seed"`
+	ScorePerturbation float64              `json:"score_perturbation"`
+	Alternatives      []SMAAAlternativeDTO `json:"alternatives"`
+}
+
+// CalculationTraceDTO returns the stored trace document as is
+type CalculationTraceDTO struct {
+	ProjectID   uint            `json:"project_id"`
+	RoundNumber int             `json:"round_number"`
+	RunID       uint            `json:"run_id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Trace       json.RawMessage `json:"trace"`
+}
+
+type CalculationRunDTO struct {
+	RunID              uint            `json:"run_id"`
+	ProjectID          uint            `json:"project_id"`
+	RoundNumber        int             `json:"round_number"`
+	TriggeredByAdminID uint            `json:"triggered_by_admin_id"`
+	AggregationMethod  string          `json:"aggregation_method"`
+	GroupMode          string          `json:"group_mode"`
+	WeightingMode      string          `json:"weighting_mode"`
+	Normalization      string          `json:"normalization"`
+	TiePolicy          string          `json:"tie_policy"`
+	TieBreakRule       string          `json:"tie_break_rule"`
+	MissingScorePolicy string          `json:"missing_score_policy"`
+	Methods            json.RawMessage `json:"methods"`
+	Weights            json.RawMessage `json:"weights"`
+	InputHash          string          `json:"input_hash"`
+	ResultHash         string          `json:"result_hash"`
+	CreatedAt          time.Time       `json:"created_at"`
+}
+
+type RunSnapshotDTO struct {
+	RunID       uint   `json:"run_id"`
+	ProjectID   uint   `json:"project_id"`
+	RoundNumber int    `json:"round_number"`
+	InputHash   string `json:"input_hash"`
+	// InputHashValid: false berarti snapshot tidak lagi sesuai dengan hash yang tersimpan
+	InputHashValid bool            `json:"input_hash_valid"`
+	Snapshot       json.RawMessage `json:"snapshot"`
+}
+
+type ReplayRankDTO struct {
+	AlternativeID uint    `json:"alternative_id"`
+	Rank          int     `json:"rank"`
+	Score         float64 `json:"score"`
+	StoredRank    int     `json:"stored_rank"`
+}
+
+// RunReplayDTO compares a recalculation from the snapshot with the results stored for the run
+type RunReplayDTO struct {
+	RunID            uint            `json:"run_id"`
+	ProjectID        uint            `json:"project_id"`
+	InputHash        string          `json:"input_hash"`
+	InputHashValid   bool            `json:"input_hash_valid"`
+	ResultHash       string          `json:"result_hash"`
+	ReplayResultHash string          `json:"replay_result_hash"`
+	Reproducible     bool            `json:"reproducible"`
+	FinalRanking     []ReplayRankDTO `json:"final_ranking"`
+}
+
+type SimulateCriteriaWeightInput struct {
+	CriteriaID uint    `json:"criteria_id" binding:"required"`
+	Weight     float64 `json:"weight" binding:"gte=0,lte=1"`
+}
+
+type SimulateGroupWeightInput struct {
+	ProjectDMID uint    `json:"project_dm_id" binding:"required"`
+	GroupWeight float64 `json:"group_weight" binding:"gt=0,lte=10"`
+}
+
+type SimulateScoreInput struct {
+	ProjectDMID   uint    `json:"project_dm_id" binding:"required"`
+	AlternativeID uint    `json:"alternative_id" binding:"required"`
+	CriteriaID    uint    `json:"criteria_id" binding:"required"`
+	ScoreValue    float64 `json:"score_value" binding:"gte=0"`
+}
+
+// SimulateInput overrides parts of the project's current inputs for a what-if calculation; empty fields keep the stored values
+type SimulateInput struct {
+	// CriteriaWeights menggantikan bobot lokal admin (Criteria.Weight) per kriteria
+	CriteriaWeights   []SimulateCriteriaWeightInput `json:"criteria_weights" binding:"omitempty,dive"`
+	GroupWeights      []SimulateGroupWeightInput    `json:"group_weights" binding:"omitempty,dive"`
+	AggregationMethod string                        `json:"aggregation_method" binding:"omitempty,oneof=BORDA COPELAND KEMENY SCHULZE"`
+	Scores            []SimulateScoreInput          `json:"scores" binding:"omitempty,dive"`
+}
+
+type SimulatedRankDTO struct {
+	AlternativeID uint    `json:"alternative_id"`
+	Name          string  `json:"name"`
+	Rank          int     `json:"rank"`
+	Score         float64 `json:"score"`
+	Tied          bool    `json:"tied"`
+}
+
+type SimulatedDMRankingDTO struct {
+	ProjectDMID uint               `json:"project_dm_id"`
+	DMUserID    uint               `json:"dm_user_id"`
+	Method      string             `json:"method"`
+	GroupWeight float64            `json:"group_weight"`
+	Ranks       []SimulatedRankDTO `json:"ranks"`
+}
+
+// SimulationDTO is the outcome of a what-if calculation; nothing of it is stored
+type SimulationDTO struct {
+	ProjectID         uint   `json:"project_id"`
+	RoundNumber       int    `json:"round_number"`
+	AggregationMethod string `json:"aggregation_method"`
+	GroupMode         string `json:"group_mode"`
+	WeightingMode     string `json:"weighting_mode"`
+	// DecisionMakers kosong pada mode AIJ karena tidak ada ranking individual
+	DecisionMakers []SimulatedDMRankingDTO `json:"decision_makers"`
+	FinalRanking   []SimulatedRankDTO      `json:"final_ranking"`
+}
+
+type MissingScoreCellDTO struct {
+	AlternativeID   uint   `json:"alternative_id"`
+	AlternativeName string `json:"alternative_name"`
+	CriteriaID      uint   `json:"criteria_id"`
+	CriteriaName    string `json:"criteria_name"`
+}
+
+type DMScoreCompletenessDTO struct {
+	ProjectDMID uint `json:"project_dm_id"`
+	DMUserID    uint `json:"dm_user_id"`
+	// Expected adalah jumlah sel alternatif × kriteria daun, Filled yang sudah berisi skor
+	Expected int                   `json:"expected"`
+	Filled   int                   `json:"filled"`
+	Complete bool                  `json:"complete"`
+	Missing  []MissingScoreCellDTO `json:"missing"`
+}
+
+// ScoreCompletenessDTO reports the score cells each DM still has to fill in a round
+type ScoreCompletenessDTO struct {
+	ProjectID          uint                     `json:"project_id"`
+	RoundNumber        int                      `json:"round_number"`
+	MissingScorePolicy string                   `json:"missing_score_policy"`
+	Complete           bool                     `json:"complete"`
+	DecisionMakers     []DMScoreCompletenessDTO `json:"decision_makers"`
 }
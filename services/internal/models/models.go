@@ -26,14 +26,26 @@ type User struct {
 }
 
 type DecisionProject struct {
-	ProjectID         uint      `gorm:"primaryKey;column:project_id" json:"project_id"`
-	CompanyID         uint      `gorm:"not null;column:company_id" json:"company_id"`
-	CreatedByAdminID  uint      `gorm:"not null;column:created_by_admin_id" json:"created_by_admin_id"`
-	ProjectName       string    `gorm:"not null;column:project_name" json:"project_name"`
-	Description       string    `gorm:"type:text;column:description" json:"description"`
-	Status            string    `gorm:"type:varchar(50);default:'setup';column:status;check:status IN ('setup','scoring','completed')" json:"status"`
-	AggregationMethod string    `gorm:"type:varchar(50);default:'BORDA';column:aggregation_method;check:aggregation_method IN ('BORDA','COPELAND','LAINNYA')" json:"aggregation_method"`
-	CreatedAt         time.Time `gorm:"autoCreateTime;column:created_at" json:"created_at"`
+	ProjectID         uint    `gorm:"primaryKey;column:project_id" json:"project_id"`
+	CompanyID         uint    `gorm:"not null;column:company_id" json:"company_id"`
+	CreatedByAdminID  uint    `gorm:"not null;column:created_by_admin_id" json:"created_by_admin_id"`
+	ProjectName       string  `gorm:"not null;column:project_name" json:"project_name"`
+	Description       string  `gorm:"type:text;column:description" json:"description"`
+	Status            string  `gorm:"type:varchar(50);default:'setup';column:status;check:status IN ('setup','scoring','completed')" json:"status"`
+	AggregationMethod string  `gorm:"type:varchar(50);default:'BORDA';column:aggregation_method;check:aggregation_method IN ('BORDA','COPELAND','KEMENY','SCHULZE','LAINNYA')" json:"aggregation_method"`
+	WeightingMode     string  `gorm:"type:varchar(50);default:'ADMIN';column:weighting_mode;check:weighting_mode IN ('ADMIN','DM_DIRECT','BLEND','ENTROPY','ENTROPY_POOLED','CRITIC','CRITIC_POOLED')" json:"weighting_mode"`
+	WeightBlend       float64 `gorm:"type:decimal(5,4);default:0.5;column:weight_blend" json:"weight_blend"`
+	Normalization     string  `gorm:"type:varchar(50);default:'VECTOR';column:normalization;check:normalization IN ('VECTOR','LINEAR_MAX','MIN_MAX','LINEAR_SUM')" json:"normalization"`
+	TiePolicy         string  `gorm:"type:varchar(50);default:'SHARED';column:tie_policy;check:tie_policy IN ('SHARED','FRACTIONAL','TIEBREAK')" json:"tie_policy"`
+	TieBreakRule      string  `gorm:"type:varchar(50);default:'ALTERNATIVE_ID';column:tie_break_rule;check:tie_break_rule IN ('ALTERNATIVE_ID','FIRST_PLACES')" json:"tie_break_rule"`
+	GroupMode         string  `gorm:"type:varchar(50);default:'AIP';column:group_mode;check:group_mode IN ('AIP','AIJ_ARITHMETIC','AIJ_GEOMETRIC')" json:"group_mode"`
+	// MissingScorePolicy: cara menangani sel skor yang kosong saat perhitungan
+	MissingScorePolicy string `gorm:"type:varchar(50);default:'BLOCK';column:missing_score_policy;check:missing_score_policy IN ('BLOCK','IMPUTE_MEAN','IMPUTE_MEDIAN','EXCLUDE_DM')" json:"missing_score_policy"`
+	// CurrentRound adalah putaran penilaian (Delphi) yang sedang berjalan; input DM selalu masuk ke putaran ini
+	CurrentRound int `gorm:"not null;default:1;column:current_round" json:"current_round"`
+	// ConsensusThreshold: putaran baru hanya boleh dibuka bila Kendall's W di bawah nilai ini
+	ConsensusThreshold float64   `gorm:"type:decimal(5,4);default:0.7;column:consensus_threshold" json:"consensus_threshold"`
+	CreatedAt          time.Time `gorm:"autoCreateTime;column:created_at" json:"created_at"`
 
 	Company Company `gorm:"foreignKey:CompanyID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
 	Creator User    `gorm:"foreignKey:CreatedByAdminID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
@@ -61,6 +73,13 @@ type Criteria struct {
 	Code             string  `gorm:"type:varchar(20);column:code" json:"code"`
 	Type             string  `gorm:"type:varchar(50);not null;column:type;check:type IN ('benefit','cost')" json:"type"`
 	Weight           float64 `gorm:"type:decimal(5,4);default:0;column:weight" json:"weight"`
+	// ScaleType: NUMERIC, LIKERT_5 atau ORDINAL; LIKERT_5 dan ORDINAL disimpan sebagai rentang 1..n dengan step 1
+	ScaleType string   `gorm:"type:varchar(20);default:'NUMERIC';column:scale_type;check:scale_type IN ('NUMERIC','LIKERT_5','ORDINAL')" json:"scale_type"`
+	ScaleMin  *float64 `gorm:"type:decimal(10,4);column:scale_min" json:"scale_min"`
+	ScaleMax  *float64 `gorm:"type:decimal(10,4);column:scale_max" json:"scale_max"`
+	ScaleStep *float64 `gorm:"type:decimal(10,4);column:scale_step" json:"scale_step"`
+	// ScaleLabels adalah array JSON berisi label tiap nilai skala, mulai dari nilai 1
+	ScaleLabels string `gorm:"type:text;column:scale_labels" json:"scale_labels"`
 
 	DecisionProject DecisionProject `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
 	ParentCriteria  *Criteria       `gorm:"foreignKey:ParentCriteriaID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
@@ -71,7 +90,7 @@ type ProjectDecisionMaker struct {
 	ProjectDMID uint    `gorm:"primaryKey;column:project_dm_id" json:"project_dm_id"`
 	ProjectID   uint    `gorm:"not null;column:project_id" json:"project_id"`
 	DMUserID    uint    `gorm:"not null;column:dm_user_id" json:"dm_user_id"`
-	Method      string  `gorm:"type:varchar(50);not null;column:method;check:method IN ('TOPSIS')" json:"method"`
+	Method      string  `gorm:"type:varchar(50);not null;column:method;check:method IN ('TOPSIS','SAW','WP','AHP')" json:"method"`
 	GroupWeight float64 `gorm:"type:decimal(5,4);default:1.0;column:group_weight" json:"group_weight"`
 
 	DecisionProject DecisionProject `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
@@ -84,10 +103,11 @@ type ProjectDecisionMaker struct {
 
 type DMInputScore struct {
 	ScoreID uint `gorm:"primaryKey;column:score_id" json:"score_id"`
-	// Tambahkan uniqueIndex agar 1 DM hanya bisa memberi 1 Nilai untuk 1 Kriteria pada 1 Alternatif
+	// Tambahkan uniqueIndex agar 1 DM hanya bisa memberi 1 Nilai untuk 1 Kriteria pada 1 Alternatif di setiap putaran
 	ProjectDMID   uint    `gorm:"not null;column:project_dm_id;uniqueIndex:idx_score_dm_alt_crit" json:"project_dm_id"`
 	AlternativeID uint    `gorm:"not null;column:alternative_id;uniqueIndex:idx_score_dm_alt_crit" json:"alternative_id"`
 	CriteriaID    uint    `gorm:"not null;column:criteria_id;uniqueIndex:idx_score_dm_alt_crit" json:"criteria_id"`
+	RoundNumber   int     `gorm:"not null;default:1;column:round_number;uniqueIndex:idx_score_dm_alt_crit" json:"round_number"`
 	ScoreValue    float64 `gorm:"type:decimal(10,4);not null;column:score_value" json:"score_value"`
 
 	ProjectDecisionMaker ProjectDecisionMaker `gorm:"foreignKey:ProjectDMID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
@@ -106,6 +126,7 @@ type DMInputPairwise struct {
 	Criteria1ID      uint    `gorm:"not null;column:criteria_1_id" json:"criteria_1_id"`
 	Criteria2ID      uint    `gorm:"not null;column:criteria_2_id" json:"criteria_2_id"`
 	ParentCriteriaID *uint   `gorm:"column:parent_criteria_id" json:"parent_criteria_id"`
+	RoundNumber      int     `gorm:"not null;default:1;column:round_number" json:"round_number"`
 	Value            float64 `gorm:"type:decimal(10,4);not null;column:value" json:"value"`
 
 	ProjectDecisionMaker ProjectDecisionMaker `gorm:"foreignKey:ProjectDMID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
@@ -123,6 +144,7 @@ type DMInputDirectWeight struct {
 	WeightID    uint    `gorm:"primaryKey;column:weight_id" json:"weight_id"`
 	ProjectDMID uint    `gorm:"not null;column:project_dm_id" json:"project_dm_id"`
 	CriteriaID  uint    `gorm:"not null;column:criteria_id" json:"criteria_id"`
+	RoundNumber int     `gorm:"not null;default:1;column:round_number" json:"round_number"`
 	WeightValue float64 `gorm:"type:decimal(5,4);not null;column:weight_value" json:"weight_value"`
 
 	ProjectDecisionMaker ProjectDecisionMaker `gorm:"foreignKey:ProjectDMID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
@@ -137,16 +159,77 @@ func (DMInputDirectWeight) TableName() string {
 type ResultRanking struct {
 	ResultID uint `gorm:"primaryKey;column:result_id" json:"result_id"`
 	// Tambahkan uniqueIndex:
-	// Kombinasi Project + Alternatif + DM (bisa NULL) harus unik.
-	// Artinya: Satu alternatif hanya boleh punya satu ranking final (DM=NULL) atau satu ranking per DM.
+	// Kombinasi Project + Alternatif + DM (bisa NULL) + Putaran harus unik.
+	// Artinya: Satu alternatif hanya boleh punya satu ranking final (DM=NULL) atau satu ranking per DM di setiap putaran.
 	ProjectID     uint  `gorm:"not null;column:project_id;uniqueIndex:idx_result_proj_alt_dm" json:"project_id"`
 	AlternativeID uint  `gorm:"not null;column:alternative_id;uniqueIndex:idx_result_proj_alt_dm" json:"alternative_id"`
 	ProjectDMID   *uint `gorm:"column:project_dm_id;uniqueIndex:idx_result_proj_alt_dm" json:"project_dm_id"`
+	RoundNumber   int   `gorm:"not null;default:1;column:round_number;uniqueIndex:idx_result_proj_alt_dm" json:"round_number"`
+	// RunID: setiap perhitungan menyimpan hasilnya sebagai run baru, hasil lama tidak ditimpa
+	RunID *uint `gorm:"column:run_id;uniqueIndex:idx_result_proj_alt_dm" json:"run_id"`
 
 	FinalScore float64 `gorm:"type:decimal(10,6);not null;column:final_score" json:"final_score"`
 	Rank       int     `gorm:"not null;column:rank" json:"rank"`
+	// Normalization yang dipakai metode DM (hanya TOPSIS yang dapat dipilih); kosong untuk hasil aggregate
+	Normalization string `gorm:"type:varchar(50);column:normalization" json:"normalization,omitempty"`
+	// IsTied menandai alternatif yang skornya sama dengan alternatif lain pada ranking ini
+	IsTied bool `gorm:"default:false;column:is_tied" json:"is_tied"`
 
 	DecisionProject      DecisionProject       `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
 	Alternative          Alternative           `gorm:"foreignKey:AlternativeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
 	ProjectDecisionMaker *ProjectDecisionMaker `gorm:"foreignKey:ProjectDMID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	CalculationRun       *CalculationRun       `gorm:"foreignKey:RunID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
+
+// CalculationRun is one execution of the calculation with the settings it ran with.
+// Results and the trace belong to a run, so recalculating keeps the earlier runs.
+type CalculationRun struct {
+	RunID              uint   `gorm:"primaryKey;column:run_id" json:"run_id"`
+	ProjectID          uint   `gorm:"not null;column:project_id;index:idx_run_proj_round" json:"project_id"`
+	RoundNumber        int    `gorm:"not null;default:1;column:round_number;index:idx_run_proj_round" json:"round_number"`
+	TriggeredByAdminID uint   `gorm:"not null;column:triggered_by_admin_id" json:"triggered_by_admin_id"`
+	AggregationMethod  string `gorm:"type:varchar(50);column:aggregation_method" json:"aggregation_method"`
+	GroupMode          string `gorm:"type:varchar(50);column:group_mode" json:"group_mode"`
+	WeightingMode      string `gorm:"type:varchar(50);column:weighting_mode" json:"weighting_mode"`
+	Normalization      string `gorm:"type:varchar(50);column:normalization" json:"normalization"`
+	TiePolicy          string `gorm:"type:varchar(50);column:tie_policy" json:"tie_policy"`
+	TieBreakRule       string `gorm:"type:varchar(50);column:tie_break_rule" json:"tie_break_rule"`
+	MissingScorePolicy string `gorm:"type:varchar(50);column:missing_score_policy" json:"missing_score_policy"`
+	// Methods dan Weights (JSON) per project_dm_id: metode DM dan bobot kriteria daun yang dipakai; 0 = DM kelompok AIJ
+	Methods string `gorm:"type:jsonb;column:methods" json:"methods"`
+	Weights string `gorm:"type:jsonb;column:weights" json:"weights"`
+	// InputSnapshot menyimpan seluruh input apa adanya (text, bukan jsonb, agar byte yang di-hash tidak berubah)
+	InputSnapshot string    `gorm:"type:text;column:input_snapshot" json:"-"`
+	InputHash     string    `gorm:"type:varchar(64);column:input_hash" json:"input_hash"`
+	ResultHash    string    `gorm:"type:varchar(64);column:result_hash" json:"result_hash"`
+	CreatedAt     time.Time `gorm:"autoCreateTime;column:created_at" json:"created_at"`
+
+	DecisionProject DecisionProject `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
+
+// ProjectRound is one Delphi-style evaluation round of a project. Inputs and results of every
+// round are kept; a round is closed when the admin opens the next one.
+type ProjectRound struct {
+	RoundID     uint       `gorm:"primaryKey;column:round_id" json:"round_id"`
+	ProjectID   uint       `gorm:"not null;column:project_id;uniqueIndex:idx_round_proj_number" json:"project_id"`
+	RoundNumber int        `gorm:"not null;column:round_number;uniqueIndex:idx_round_proj_number" json:"round_number"`
+	Status      string     `gorm:"type:varchar(50);default:'open';column:status;check:status IN ('open','closed')" json:"status"`
+	KendallW    *float64   `gorm:"type:decimal(10,6);column:kendall_w" json:"kendall_w"`
+	OpenedAt    time.Time  `gorm:"autoCreateTime;column:opened_at" json:"opened_at"`
+	ClosedAt    *time.Time `gorm:"column:closed_at" json:"closed_at"`
+
+	DecisionProject DecisionProject `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
+
+// CalculationTrace keeps the intermediate values of one calculation run as JSON
+type CalculationTrace struct {
+	TraceID     uint      `gorm:"primaryKey;column:trace_id" json:"trace_id"`
+	ProjectID   uint      `gorm:"not null;column:project_id;index:idx_trace_proj_round" json:"project_id"`
+	RoundNumber int       `gorm:"not null;default:1;column:round_number;index:idx_trace_proj_round" json:"round_number"`
+	RunID       *uint     `gorm:"column:run_id;uniqueIndex:idx_trace_run" json:"run_id"`
+	Trace       string    `gorm:"type:jsonb;not null;column:trace" json:"trace"`
+	CreatedAt   time.Time `gorm:"autoCreateTime;column:created_at" json:"created_at"`
+
+	DecisionProject DecisionProject `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	CalculationRun  *CalculationRun `gorm:"foreignKey:RunID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
 }
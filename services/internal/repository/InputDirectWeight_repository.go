@@ -7,8 +7,8 @@ import (
 )
 
 type InputDirectWeightRepository interface {
-	BatchUsertWeights(projectDMID uint, weights []models.DMInputDirectWeight) error
-	GetDIrectWeightls(projectlDMID uint) ([]models.DMInputDirectWeight, error)
+	BatchUsertWeights(projectDMID uint, roundNumber int, weights []models.DMInputDirectWeight) error
+	GetDIrectWeightls(projectlDMID uint, roundNumber int) ([]models.DMInputDirectWeight, error)
 }
 
 type inputDirectWeightRepository struct {
@@ -19,13 +19,13 @@ func NewInputDirectWeigtrepository(db *gorm.DB) InputDirectWeightRepository {
 	return &inputDirectWeightRepository{db: db}
 }
 
-func (r *inputDirectWeightRepository) BatchUsertWeights(projectDMID uint, weights []models.DMInputDirectWeight) error {
+func (r *inputDirectWeightRepository) BatchUsertWeights(projectDMID uint, roundNumber int, weights []models.DMInputDirectWeight) error {
 	if len(weights) == 0 {
 		return nil
 	}
 
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("project_dm_id = ?", projectDMID).
+		if err := tx.Where("project_dm_id = ? AND round_number = ?", projectDMID, roundNumber).
 			Delete(&models.DMInputDirectWeight{}).Error; err != nil {
 			return err
 		}
@@ -37,10 +37,10 @@ func (r *inputDirectWeightRepository) BatchUsertWeights(projectDMID uint, weight
 	})
 }
 
-func (r *inputDirectWeightRepository) GetDIrectWeightls(projectDMID uint) ([]models.DMInputDirectWeight, error) {
+func (r *inputDirectWeightRepository) GetDIrectWeightls(projectDMID uint, roundNumber int) ([]models.DMInputDirectWeight, error) {
 	var weights []models.DMInputDirectWeight
 
-	err := r.db.Where("project_dm_id = ?", projectDMID).Find(&weights).Error
+	err := r.db.Where("project_dm_id = ? AND round_number = ?", projectDMID, roundNumber).Find(&weights).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"services/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type InputPairwiseRepository interface {
+	BatchUpsertPairwise(projectDMID uint, roundNumber int, comparisons []models.DMInputPairwise) error
+	GetPairwise(projectDMID uint, roundNumber int) ([]models.DMInputPairwise, error)
+}
+
+type inputPairwiseRepository struct {
+	db *gorm.DB
+}
+
+func NewInputPairwiseRepository(db *gorm.DB) InputPairwiseRepository {
+	return &inputPairwiseRepository{db: db}
+}
+
+// BatchUpsertPairwise replaces the DM's comparisons of the sibling groups in the submission for one round;
+// comparisons of other groups and other rounds are kept
+func (r *inputPairwiseRepository) BatchUpsertPairwise(projectDMID uint, roundNumber int, comparisons []models.DMInputPairwise) error {
+	if len(comparisons) == 0 {
+		return nil
+	}
+
+	// Grup kriteria akar memiliki parent_criteria_id NULL
+	var parentIDs []uint
+	hasRoot := false
+	seen := make(map[uint]bool)
+	for _, c := range comparisons {
+		if c.ParentCriteriaID == nil {
+			hasRoot = true
+		} else if !seen[*c.ParentCriteriaID] {
+			seen[*c.ParentCriteriaID] = true
+			parentIDs = append(parentIDs, *c.ParentCriteriaID)
+		}
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("project_dm_id = ? AND round_number = ?", projectDMID, roundNumber)
+		switch {
+		case hasRoot && len(parentIDs) > 0:
+			query = query.Where("parent_criteria_id IS NULL OR parent_criteria_id IN ?", parentIDs)
+		case hasRoot:
+			query = query.Where("parent_criteria_id IS NULL")
+		default:
+			query = query.Where("parent_criteria_id IN ?", parentIDs)
+		}
+		if err := query.Delete(&models.DMInputPairwise{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&comparisons).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *inputPairwiseRepository) GetPairwise(projectDMID uint, roundNumber int) ([]models.DMInputPairwise, error) {
+	var comparisons []models.DMInputPairwise
+	err := r.db.Where("project_dm_id = ? AND round_number = ?", projectDMID, roundNumber).Order("comparison_id").Find(&comparisons).Error
+	if err != nil {
+		return nil, err
+	}
+	return comparisons, nil
+}
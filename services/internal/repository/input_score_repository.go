@@ -7,8 +7,8 @@ import (
 )
 
 type InputScoreRepository interface {
-	BatchUpsertInputScores(projectDMID uint, inputScores []models.DMInputScore) error
-	GetScores(projectDMID uint) ([]models.DMInputScore, error)
+	BatchUpsertInputScores(projectDMID uint, roundNumber int, inputScores []models.DMInputScore) error
+	GetScores(projectDMID uint, roundNumber int) ([]models.DMInputScore, error)
 	CreateScore(score *models.DMInputScore) error
 }
 
@@ -20,12 +20,13 @@ func NewInputScoreRepository(db *gorm.DB) InputScoreRepository {
 	return &inputScoreRepository{db: db}
 }
 
-func (r *inputScoreRepository) BatchUpsertInputScores(projectDMID uint, inputScores []models.DMInputScore) error {
+// BatchUpsertInputScores replaces the DM's scores of one round; other rounds are kept
+func (r *inputScoreRepository) BatchUpsertInputScores(projectDMID uint, roundNumber int, inputScores []models.DMInputScore) error {
 	if len(inputScores) == 0 {
 		return nil
 	}
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("project_dm_id = ?", projectDMID).Delete(&models.DMInputScore{}).Error; err != nil {
+		if err := tx.Where("project_dm_id = ? AND round_number = ?", projectDMID, roundNumber).Delete(&models.DMInputScore{}).Error; err != nil {
 			return err
 		}
 		if err := tx.Create(&inputScores).Error; err != nil {
@@ -35,9 +36,9 @@ func (r *inputScoreRepository) BatchUpsertInputScores(projectDMID uint, inputSco
 	})
 }
 
-func (r *inputScoreRepository) GetScores(projectDMID uint) ([]models.DMInputScore, error) {
+func (r *inputScoreRepository) GetScores(projectDMID uint, roundNumber int) ([]models.DMInputScore, error) {
 	var scores []models.DMInputScore
-	err := r.db.Where("project_dm_id = ?", projectDMID).Find(&scores).Error
+	err := r.db.Where("project_dm_id = ? AND round_number = ?", projectDMID, roundNumber).Find(&scores).Error
 	if err != nil {
 		return nil, err
 	}
@@ -47,8 +48,8 @@ func (r *inputScoreRepository) GetScores(projectDMID uint) ([]models.DMInputScor
 func (r *inputScoreRepository) CreateScore(score *models.DMInputScore) error {
 	// Check if score exists for this criteria and alternative
 	var existing models.DMInputScore
-	err := r.db.Where("project_dm_id = ? AND alternative_id = ? AND criteria_id = ?",
-		score.ProjectDMID, score.AlternativeID, score.CriteriaID).First(&existing).Error
+	err := r.db.Where("project_dm_id = ? AND alternative_id = ? AND criteria_id = ? AND round_number = ?",
+		score.ProjectDMID, score.AlternativeID, score.CriteriaID, score.RoundNumber).First(&existing).Error
 
 	if err == nil {
 		// Update existing
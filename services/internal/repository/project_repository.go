@@ -13,6 +13,7 @@ type ProjectRepository interface {
 	UpdateProject(project *models.DecisionProject) error
 	DeleteProject(projectID uint, companyID uint) error
 	GetProjectsByDM(userID uint) ([]models.DecisionProject, error)
+	GetCurrentRound(projectID uint) (int, error)
 }
 
 type projectRepository struct {
@@ -65,3 +66,16 @@ func (r *projectRepository) GetProjectsByDM(userID uint) ([]models.DecisionProje
 	}
 	return projects, nil
 }
+
+// GetCurrentRound returns the round DM inputs are currently written to
+func (r *projectRepository) GetCurrentRound(projectID uint) (int, error) {
+	var project models.DecisionProject
+	err := r.db.Select("current_round").Where("project_id = ?", projectID).First(&project).Error
+	if err != nil {
+		return 0, err
+	}
+	if project.CurrentRound == 0 {
+		return 1, nil
+	}
+	return project.CurrentRound, nil
+}
@@ -7,9 +7,8 @@ import (
 )
 
 type ResultRankingRepository interface {
-	ClearRangkings(projectID uint) error
-	CreateRankings(rangkings []models.ResultRanking) error
-	GetRangkings(projectID uint) ([]models.ResultRanking, error)
+	GetRangkings(projectID uint, roundNumber int) ([]models.ResultRanking, error)
+	GetRankingsByRun(runID uint) ([]models.ResultRanking, error)
 }
 
 type resultRankingRepository struct {
@@ -20,19 +19,16 @@ func NewResultRankingRepository(db *gorm.DB) ResultRankingRepository {
 	return &resultRankingRepository{db: db}
 }
 
-func (r *resultRankingRepository) ClearRangkings(projectID uint) error {
-	return r.db.Where("project_id = ?", projectID).Delete(&models.ResultRanking{}).Error
-}
-
-func (r *resultRankingRepository) CreateRankings(rangkings []models.ResultRanking) error {
-	if len(rangkings) == 0 {
-		return nil
-	}
-	return r.db.Create(&rangkings).Error
+// GetRangkings returns the rankings of the latest run of the round
+func (r *resultRankingRepository) GetRangkings(projectID uint, roundNumber int) ([]models.ResultRanking, error) {
+	var result []models.ResultRanking
+	latestRun := r.db.Model(&models.CalculationRun{}).Select("MAX(run_id)").Where("project_id = ? AND round_number = ?", projectID, roundNumber)
+	err := r.db.Where("project_id = ? AND run_id = (?)", projectID, latestRun).Order("project_dm_id IS NOT NULL , project_dm_id, rank").Find(&result).Error
+	return result, err
 }
 
-func (r *resultRankingRepository) GetRangkings(projectID uint) ([]models.ResultRanking, error) {
+func (r *resultRankingRepository) GetRankingsByRun(runID uint) ([]models.ResultRanking, error) {
 	var result []models.ResultRanking
-	err := r.db.Where("project_id = ?", projectID).Order("project_dm_id IS NOT NULL , project_dm_id, rank").Find(&result).Error
+	err := r.db.Where("run_id = ?", runID).Order("project_dm_id IS NOT NULL , project_dm_id, rank").Find(&result).Error
 	return result, err
 }
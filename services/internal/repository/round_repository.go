@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"errors"
+	"services/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrRoundAdvanced is returned when another request opened the next round first
+var ErrRoundAdvanced = errors.New("the round has already been advanced by another request")
+
+type ProjectRoundRepository interface {
+	GetRounds(projectID uint) ([]models.ProjectRound, error)
+	OpenNextRound(projectID uint, fromRound int, kendallW *float64) (*models.ProjectRound, error)
+}
+
+type projectRoundRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRoundRepository(db *gorm.DB) ProjectRoundRepository {
+	return &projectRoundRepository{db: db}
+}
+
+func (r *projectRoundRepository) GetRounds(projectID uint) ([]models.ProjectRound, error) {
+	var rounds []models.ProjectRound
+	err := r.db.Where("project_id = ?", projectID).Order("round_number").Find(&rounds).Error
+	if err != nil {
+		return nil, err
+	}
+	return rounds, nil
+}
+
+// OpenNextRound closes fromRound with its consensus, opens the next one and copies every DM's
+// scores, direct weights and pairwise comparisons into it as the starting point for revision. All of it runs in one transaction.
+// The project row stays locked until the commit; ErrRoundAdvanced is returned when fromRound is no longer current.
+func (r *projectRoundRepository) OpenNextRound(projectID uint, fromRound int, kendallW *float64) (*models.ProjectRound, error) {
+	var next models.ProjectRound
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var project models.DecisionProject
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("project_id = ?", projectID).First(&project).Error; err != nil {
+			return err
+		}
+		current := project.CurrentRound
+		if current == 0 {
+			current = 1
+		}
+		if current != fromRound {
+			return ErrRoundAdvanced
+		}
+		now := time.Now()
+
+		// Putaran lama bisa belum tercatat (proyek lama selalu mulai di putaran 1)
+		closed := models.ProjectRound{ProjectID: projectID, RoundNumber: current}
+		if err := tx.Where("project_id = ? AND round_number = ?", projectID, current).
+			Attrs(models.ProjectRound{OpenedAt: project.CreatedAt}).
+			FirstOrCreate(&closed).Error; err != nil {
+			return err
+		}
+		closed.Status = "closed"
+		closed.KendallW = kendallW
+		closed.ClosedAt = &now
+		if err := tx.Save(&closed).Error; err != nil {
+			return err
+		}
+
+		next = models.ProjectRound{ProjectID: projectID, RoundNumber: current + 1, Status: "open", OpenedAt: now}
+		if err := tx.Create(&next).Error; err != nil {
+			return err
+		}
+
+		dmIDs := tx.Model(&models.ProjectDecisionMaker{}).Select("project_dm_id").Where("project_id = ?", projectID)
+
+		var scores []models.DMInputScore
+		if err := tx.Where("project_dm_id IN (?) AND round_number = ?", dmIDs, current).Find(&scores).Error; err != nil {
+			return err
+		}
+		for i := range scores {
+			scores[i].ScoreID = 0
+			scores[i].RoundNumber = next.RoundNumber
+		}
+		if len(scores) > 0 {
+			if err := tx.Create(&scores).Error; err != nil {
+				return err
+			}
+		}
+
+		var weights []models.DMInputDirectWeight
+		if err := tx.Where("project_dm_id IN (?) AND round_number = ?", dmIDs, current).Find(&weights).Error; err != nil {
+			return err
+		}
+		for i := range weights {
+			weights[i].WeightID = 0
+			weights[i].RoundNumber = next.RoundNumber
+		}
+		if len(weights) > 0 {
+			if err := tx.Create(&weights).Error; err != nil {
+				return err
+			}
+		}
+
+		var comparisons []models.DMInputPairwise
+		if err := tx.Where("project_dm_id IN (?) AND round_number = ?", dmIDs, current).Find(&comparisons).Error; err != nil {
+			return err
+		}
+		for i := range comparisons {
+			comparisons[i].ComparisonID = 0
+			comparisons[i].RoundNumber = next.RoundNumber
+		}
+		if len(comparisons) > 0 {
+			if err := tx.Create(&comparisons).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.DecisionProject{}).Where("project_id = ?", projectID).
+			Update("current_round", next.RoundNumber).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"services/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CalculationRunRepository interface {
+	CreateRun(run *models.CalculationRun, rankings []models.ResultRanking, trace *models.CalculationTrace) error
+	GetRuns(projectID uint) ([]models.CalculationRun, error)
+	GetRun(projectID uint, runID uint) (*models.CalculationRun, error)
+	GetLatestRun(projectID uint, roundNumber int) (*models.CalculationRun, error)
+}
+
+type calculationRunRepository struct {
+	db *gorm.DB
+}
+
+func NewCalculationRunRepository(db *gorm.DB) CalculationRunRepository {
+	return &calculationRunRepository{db: db}
+}
+
+// CreateRun stores the run together with its rankings and trace in one transaction,
+// so a failure never leaves a run without results
+func (r *calculationRunRepository) CreateRun(run *models.CalculationRun, rankings []models.ResultRanking, trace *models.CalculationTrace) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+		for i := range rankings {
+			rankings[i].RunID = &run.RunID
+		}
+		if len(rankings) > 0 {
+			if err := tx.Create(&rankings).Error; err != nil {
+				return err
+			}
+		}
+		if trace != nil {
+			trace.RunID = &run.RunID
+			if err := tx.Create(trace).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *calculationRunRepository) GetRuns(projectID uint) ([]models.CalculationRun, error) {
+	var runs []models.CalculationRun
+	err := r.db.Where("project_id = ?", projectID).Order("run_id DESC").Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+func (r *calculationRunRepository) GetRun(projectID uint, runID uint) (*models.CalculationRun, error) {
+	var run models.CalculationRun
+	err := r.db.Where("project_id = ? AND run_id = ?", projectID, runID).First(&run).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *calculationRunRepository) GetLatestRun(projectID uint, roundNumber int) (*models.CalculationRun, error) {
+	var run models.CalculationRun
+	err := r.db.Where("project_id = ? AND round_number = ?", projectID, roundNumber).Order("run_id DESC").First(&run).Error
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"services/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type CalculationTraceRepository interface {
+	GetTraceByRun(runID uint) (*models.CalculationTrace, error)
+}
+
+type calculationTraceRepository struct {
+	db *gorm.DB
+}
+
+func NewCalculationTraceRepository(db *gorm.DB) CalculationTraceRepository {
+	return &calculationTraceRepository{db: db}
+}
+
+func (r *calculationTraceRepository) GetTraceByRun(runID uint) (*models.CalculationTrace, error) {
+	var trace models.CalculationTrace
+	err := r.db.Where("run_id = ?", runID).First(&trace).Error
+	if err != nil {
+		return nil, err
+	}
+	return &trace, nil
+}
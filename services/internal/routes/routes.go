@@ -104,6 +104,18 @@ func SetupInputDirectWeightRoutes(r *gin.Engine, directWeightHandler handler.Inp
 	}
 }
 
+func SetupInputPairwiseRoutes(r *gin.Engine, pairwiseHandler handler.InputPairwiseHandler) {
+	api := r.Group("/api/v1")
+	{
+		projectGroup := api.Group("/projects/:projectID", middleware.AuthMiddleware())
+		{
+
+			projectGroup.POST("/pairwise", pairwiseHandler.SubmitPairwise)
+			projectGroup.GET("/pairwise", pairwiseHandler.GetPairwise)
+		}
+	}
+}
+
 func SetupInputScoreRoutes(r *gin.Engine, scoreHandler handler.InputScoreHandler) {
 	api := r.Group("/api/v1")
 	{
@@ -126,6 +138,27 @@ func SetupDecisionRoutes(r *gin.Engine, decisionHandler handler.DecisionHandler)
 			projectGroup.POST("/calculate", decisionHandler.TriggerCalculation)
 
 			projectGroup.GET("/results", decisionHandler.GetResults)
+			projectGroup.GET("/results/trace", decisionHandler.GetTrace)
+			projectGroup.GET("/runs", decisionHandler.GetRuns)
+			projectGroup.GET("/runs/:runID/snapshot", decisionHandler.GetRunSnapshot)
+			projectGroup.POST("/runs/:runID/replay", decisionHandler.ReplayRun)
+			projectGroup.GET("/consensus", decisionHandler.GetConsensus)
+			projectGroup.GET("/completeness", decisionHandler.GetScoreCompleteness)
+			projectGroup.POST("/sensitivity", decisionHandler.AnalyzeSensitivity)
+			projectGroup.POST("/smaa", decisionHandler.AnalyzeSMAA)
+			projectGroup.POST("/simulate", decisionHandler.Simulate)
+		}
+	}
+}
+
+func SetupRoundRoutes(r *gin.Engine, roundHandler handler.RoundHandler) {
+	api := r.Group("/api/v1")
+	{
+		projectGroup := api.Group("/projects/:projectID", middleware.AuthMiddleware())
+		{
+			projectGroup.GET("/rounds", roundHandler.GetRounds)
+			projectGroup.POST("/rounds", roundHandler.OpenNextRound)
+			projectGroup.GET("/rounds/feedback", roundHandler.GetRoundFeedback)
 		}
 	}
 }
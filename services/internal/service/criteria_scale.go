@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"services/internal/models"
+)
+
+// Criteria score scales
+const (
+	scaleNumeric = "NUMERIC"
+	scaleLikert5 = "LIKERT_5"
+	scaleOrdinal = "ORDINAL"
+)
+
+// scaleTolerance absorbs the float rounding when a score is checked against its step
+const scaleTolerance = 1e-6
+
+// scaleDefinition is the scale part of the create and update criteria inputs
+type scaleDefinition struct {
+	scaleType string
+	min       *float64
+	max       *float64
+	step      *float64
+	labels    []string
+}
+
+// applyScale validates the scale definition and stores it on the criteria. LIKERT_5 and ORDINAL are
+// stored as the integer range 1..n so scores are checked the same way for every scale type.
+func applyScale(criteria *models.Criteria, def scaleDefinition) error {
+	criteria.ScaleLabels = ""
+	switch def.scaleType {
+	case "", scaleNumeric:
+		if len(def.labels) > 0 {
+			return errors.New("invalid criteria scale: labels are only used by LIKERT_5 and ORDINAL scales")
+		}
+		if def.min != nil && def.max != nil && *def.min >= *def.max {
+			return errors.New("invalid criteria scale: scale_min must be lower than scale_max")
+		}
+		criteria.ScaleType = scaleNumeric
+		criteria.ScaleMin, criteria.ScaleMax, criteria.ScaleStep = def.min, def.max, def.step
+		return nil
+	case scaleLikert5:
+		if len(def.labels) > 0 && len(def.labels) != 5 {
+			return errors.New("invalid criteria scale: a LIKERT_5 scale takes exactly 5 labels")
+		}
+	case scaleOrdinal:
+		if len(def.labels) < 2 {
+			return errors.New("invalid criteria scale: an ORDINAL scale needs at least 2 labels")
+		}
+	default:
+		return fmt.Errorf("invalid criteria scale: unknown scale type %s", def.scaleType)
+	}
+
+	if def.min != nil || def.max != nil || def.step != nil {
+		return errors.New("invalid criteria scale: LIKERT_5 and ORDINAL scales do not take scale_min, scale_max or scale_step")
+	}
+	points := 5
+	if def.scaleType == scaleOrdinal {
+		points = len(def.labels)
+	}
+	lowest, highest, step := 1.0, float64(points), 1.0
+	criteria.ScaleType = def.scaleType
+	criteria.ScaleMin, criteria.ScaleMax, criteria.ScaleStep = &lowest, &highest, &step
+	if len(def.labels) > 0 {
+		labels, err := json.Marshal(def.labels)
+		if err != nil {
+			return err
+		}
+		criteria.ScaleLabels = string(labels)
+	}
+	return nil
+}
+
+// scaleLabels decodes the stored labels; a missing or broken value reads as no labels
+func scaleLabels(criteria *models.Criteria) []string {
+	if criteria.ScaleLabels == "" {
+		return nil
+	}
+	var labels []string
+	if err := json.Unmarshal([]byte(criteria.ScaleLabels), &labels); err != nil {
+		return nil
+	}
+	return labels
+}
+
+// validateScore checks a score against the scale of its criteria; steps are counted from the minimum (or 0)
+func validateScore(criteria *models.Criteria, value float64) error {
+	if criteria.ScaleMin != nil && value < *criteria.ScaleMin-scaleTolerance {
+		return fmt.Errorf("invalid score: %g is below the minimum %g of criteria %s", value, *criteria.ScaleMin, criteria.Name)
+	}
+	if criteria.ScaleMax != nil && value > *criteria.ScaleMax+scaleTolerance {
+		return fmt.Errorf("invalid score: %g is above the maximum %g of criteria %s", value, *criteria.ScaleMax, criteria.Name)
+	}
+	if criteria.ScaleStep != nil && *criteria.ScaleStep > 0 {
+		base := 0.0
+		if criteria.ScaleMin != nil {
+			base = *criteria.ScaleMin
+		}
+		steps := (value - base) / *criteria.ScaleStep
+		if math.Abs(steps-math.Round(steps))*(*criteria.ScaleStep) > scaleTolerance {
+			return fmt.Errorf("invalid score: %g does not match the step %g of criteria %s", value, *criteria.ScaleStep, criteria.Name)
+		}
+	}
+	return nil
+}
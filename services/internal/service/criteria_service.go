@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"services/internal/calculations"
 	"services/internal/models"
 	"services/internal/repository"
 )
@@ -15,6 +16,11 @@ func toCriteriaDTO(criteria *models.Criteria) models.CriteriaDTO {
 		Code:             criteria.Code,
 		Type:             criteria.Type,
 		Weight:           criteria.Weight,
+		ScaleType:        criteria.ScaleType,
+		ScaleMin:         criteria.ScaleMin,
+		ScaleMax:         criteria.ScaleMax,
+		ScaleStep:        criteria.ScaleStep,
+		ScaleLabels:      scaleLabels(criteria),
 	}
 }
 
@@ -67,6 +73,15 @@ func (s *criteriaService) CreateCriteria(input models.CreateCriteriaInput, proje
 		Weight:           input.Weight,
 		ParentCriteriaID: input.ParentCriteriaID,
 	}
+	if err := applyScale(&newCriteria, scaleDefinition{
+		scaleType: input.ScaleType,
+		min:       input.ScaleMin,
+		max:       input.ScaleMax,
+		step:      input.ScaleStep,
+		labels:    input.ScaleLabels,
+	}); err != nil {
+		return nil, err
+	}
 
 	err := s.criteriaRepo.CreateCriteria(&newCriteria)
 	if err != nil {
@@ -88,6 +103,13 @@ func (s *criteriaService) GetCriteriaByProject(projectID uint, companyID uint) (
 		return nil, err
 	}
 
+	// Bobot global = bobot lokal × bobot global parent
+	localWeights := make(map[uint]float64)
+	for _, criteria := range flatList {
+		localWeights[criteria.CriteriaID] = criteria.Weight
+	}
+	globalWeights := calculations.PropagateWeights(flatList, localWeights)
+
 	dtoMap := make(map[uint]models.CriteriaDTO)
 
 	childMap := make(map[uint][]models.CriteriaDTO)
@@ -96,6 +118,7 @@ func (s *criteriaService) GetCriteriaByProject(projectID uint, companyID uint) (
 
 	for _, criteria := range flatList {
 		dto := toCriteriaDTO(&criteria)
+		dto.GlobalWeight = globalWeights[dto.CriteriaID]
 		dtoMap[dto.CriteriaID] = dto
 
 		if dto.ParentCriteriaID == nil {
@@ -155,6 +178,17 @@ func (s *criteriaService) UpdateCriteria(criteriaID uint, input models.UpdateCri
 	if input.Weight > 0 {
 		criteria.Weight = input.Weight
 	}
+	if input.ScaleType != "" {
+		if err := applyScale(criteria, scaleDefinition{
+			scaleType: input.ScaleType,
+			min:       input.ScaleMin,
+			max:       input.ScaleMax,
+			step:      input.ScaleStep,
+			labels:    input.ScaleLabels,
+		}); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.criteriaRepo.UpdateCriteria(criteria); err != nil {
 		return nil, err
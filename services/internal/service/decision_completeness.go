@@ -0,0 +1,79 @@
+package service
+
+import (
+	"services/internal/calculations"
+	"services/internal/models"
+)
+
+// GetScoreCompleteness checks every DM's scores of a round (0 = current) against the full
+// alternatives × leaf criteria grid and lists the cells that are still empty
+func (s *decisionService) GetScoreCompleteness(projectID uint, companyID uint, roundNumber int) (*models.ScoreCompletenessDTO, error) {
+	project, err := s.checkProjectAccess(projectID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if roundNumber == 0 {
+		roundNumber = currentRound(project)
+	}
+
+	assignments, err := s.projectDMRepo.GetAssignmentsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	criteria, err := s.criteriaRepo.GetCriteriaByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	alternatives, err := s.altRepo.GetAlternativeByProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := calculations.LeafCriteria(criteria)
+	criteriaNames := make(map[uint]string)
+	for _, c := range leaves {
+		criteriaNames[c.CriteriaID] = c.Name
+	}
+	altNames := make(map[uint]string)
+	for _, a := range alternatives {
+		altNames[a.AlternativeID] = a.Name
+	}
+
+	completeness := &models.ScoreCompletenessDTO{
+		ProjectID:          projectID,
+		RoundNumber:        roundNumber,
+		MissingScorePolicy: missingScorePolicy(project),
+		Complete:           true,
+		DecisionMakers:     []models.DMScoreCompletenessDTO{},
+	}
+	expected := len(leaves) * len(alternatives)
+	for _, dm := range assignments {
+		scores, err := s.scoreRepo.GetScores(dm.ProjectDMID, roundNumber)
+		if err != nil {
+			return nil, err
+		}
+		missing := calculations.MissingScores(scores, leaves, alternatives)
+
+		dmCompleteness := models.DMScoreCompletenessDTO{
+			ProjectDMID: dm.ProjectDMID,
+			DMUserID:    dm.DMUserID,
+			Expected:    expected,
+			Filled:      expected - len(missing),
+			Complete:    len(missing) == 0,
+			Missing:     []models.MissingScoreCellDTO{},
+		}
+		for _, cell := range missing {
+			dmCompleteness.Missing = append(dmCompleteness.Missing, models.MissingScoreCellDTO{
+				AlternativeID:   cell.AlternativeID,
+				AlternativeName: altNames[cell.AlternativeID],
+				CriteriaID:      cell.CriteriaID,
+				CriteriaName:    criteriaNames[cell.CriteriaID],
+			})
+		}
+		if !dmCompleteness.Complete {
+			completeness.Complete = false
+		}
+		completeness.DecisionMakers = append(completeness.DecisionMakers, dmCompleteness)
+	}
+	return completeness, nil
+}
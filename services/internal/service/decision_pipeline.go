@@ -0,0 +1,514 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"services/internal/calculations"
+	"services/internal/models"
+	"strings"
+)
+
+// Project weighting modes
+const (
+	weightingAdmin    = "ADMIN"
+	weightingDMDirect = "DM_DIRECT"
+	weightingBlend    = "BLEND"
+
+	// Any other mode names an objective weighting (e.g. ENTROPY); the _POOLED variant
+	// computes one set of weights from the score matrices of all DMs together
+	pooledWeightingSuffix = "_POOLED"
+)
+
+// calculationInput holds everything a calculation reads from the database
+type calculationInput struct {
+	project       models.DecisionProject
+	criteria      []models.Criteria
+	alternatives  []models.Alternative
+	assignments   []models.ProjectDecisionMaker
+	scores        map[uint][]models.DMInputScore
+	directWeights map[uint][]models.DMInputDirectWeight
+	pairwise      map[uint][]models.DMInputPairwise
+
+	// weightShift, when set, overrides one criterion's weight for every DM (sensitivity analysis)
+	weightShift *weightShift
+	// usedWeights, when set, records the leaf weights each DM was ranked with
+	usedWeights map[uint]map[uint]float64
+	// traces, when set, collects the intermediate values of every DM's ranking
+	traces map[uint]*dmTrace
+	// excludedCriteria[dm] holds the leaf criteria the DM is left out of under EXCLUDE_DM
+	excludedCriteria map[uint]map[uint]bool
+	// quiet skips the progress log lines; the sensitivity analysis runs the pipeline hundreds of times
+	quiet bool
+}
+
+type weightShift struct {
+	criteriaID uint
+	weight     float64
+}
+
+// dmResult is the individual ranking of one decision maker
+type dmResult struct {
+	assignment models.ProjectDecisionMaker
+	ranks      []calculations.TOPSISRank
+}
+
+type calculationOutput struct {
+	dmResults   []dmResult
+	dmRankings  []calculations.SingleDMRanking
+	aggregation *calculations.AggregationResult
+}
+
+func (s *decisionService) loadCalculationInput(project *models.DecisionProject) (*calculationInput, error) {
+	input := &calculationInput{
+		project:       *project,
+		scores:        make(map[uint][]models.DMInputScore),
+		directWeights: make(map[uint][]models.DMInputDirectWeight),
+		pairwise:      make(map[uint][]models.DMInputPairwise),
+	}
+
+	var err error
+	if input.assignments, err = s.projectDMRepo.GetAssignmentsByProjectID(project.ProjectID); err != nil {
+		return nil, err
+	}
+	if input.criteria, err = s.criteriaRepo.GetCriteriaByProjectID(project.ProjectID); err != nil {
+		return nil, err
+	}
+	if input.alternatives, err = s.altRepo.GetAlternativeByProject(project.ProjectID); err != nil {
+		return nil, err
+	}
+
+	for _, dm := range input.assignments {
+		scores, err := s.scoreRepo.GetScores(dm.ProjectDMID, currentRound(project))
+		if err != nil {
+			log.Printf("Error mendapatkan skor untuk DM %d: %v", dm.ProjectDMID, err)
+			return nil, err
+		}
+		input.scores[dm.ProjectDMID] = scores
+
+		if usesDirectWeights(project.WeightingMode) {
+			weights, err := s.directWtRepo.GetDIrectWeightls(dm.ProjectDMID, currentRound(project))
+			if err != nil {
+				return nil, err
+			}
+			input.directWeights[dm.ProjectDMID] = weights
+		}
+
+		if dm.Method == calculations.MethodAHP {
+			comparisons, err := s.pairwiseRepo.GetPairwise(dm.ProjectDMID, currentRound(project))
+			if err != nil {
+				return nil, err
+			}
+			input.pairwise[dm.ProjectDMID] = comparisons
+		}
+	}
+
+	return input, nil
+}
+
+// runCalculation ranks every DM with their own method and aggregates the rankings.
+// It only works on the in-memory input and never touches the database.
+func (s *decisionService) runCalculation(input *calculationInput) (*calculationOutput, error) {
+	input, err := applyMissingScorePolicy(input)
+	if err != nil {
+		return nil, err
+	}
+	if calculations.IsAIJ(input.project.GroupMode) {
+		return s.runGroupCalculation(input)
+	}
+
+	aggregator, err := s.resolveAggregator(&input.project)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &calculationOutput{}
+
+	// Step 1: Calculate the individual method for each DM
+	for _, dm := range input.assignments {
+		ranks, err := s.rankDM(input, dm)
+		if err != nil {
+			return nil, err
+		}
+
+		dmRanking := calculations.SingleDMRanking{
+			DMID:     dm.ProjectDMID,
+			DMWeight: dm.GroupWeight,
+		}
+		for _, r := range ranks {
+			dmRanking.RankedList = append(dmRanking.RankedList, calculations.AlternativeRank{
+				AlternativeID: r.AlternativeID,
+				Rank:          r.Rank,
+				Score:         r.FinalScore,
+				Tied:          r.Tied,
+			})
+		}
+
+		output.dmResults = append(output.dmResults, dmResult{assignment: dm, ranks: ranks})
+		output.dmRankings = append(output.dmRankings, dmRanking)
+	}
+
+	// Step 2: Calculate group aggregate
+	if !input.quiet {
+		log.Printf("=== Menghitung ranking final %s ===", aggregator.Name())
+	}
+	output.aggregation, err = aggregator.Aggregate(output.dmRankings, tieOptions(&input.project))
+	if err != nil {
+		log.Printf("ERROR: aggregator %s gagal: %v", aggregator.Name(), err)
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// runGroupCalculation (AIJ) merges the DMs' score matrices into one group matrix weighted by
+// GroupWeight and ranks it once with TOPSIS. There are no individual rankings in this mode.
+func (s *decisionService) runGroupCalculation(input *calculationInput) (*calculationOutput, error) {
+	mode := input.project.GroupMode
+	var scoreSets [][]models.DMInputScore
+	var dmWeights []float64
+	for _, dm := range input.assignments {
+		scoreSets = append(scoreSets, input.scores[dm.ProjectDMID])
+		dmWeights = append(dmWeights, dm.GroupWeight)
+	}
+
+	leaves := calculations.LeafCriteria(input.criteria)
+	groupScores, err := calculations.AggregateScoreMatrices(scoreSets, dmWeights, leaves, input.alternatives, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// DM kelompok semu: matriks gabungan dan bobot langsung rata-rata tertimbang
+	group := models.ProjectDecisionMaker{Method: calculations.MethodTOPSIS, GroupWeight: 1}
+	groupInput := *input
+	groupInput.assignments = []models.ProjectDecisionMaker{group}
+	groupInput.scores = map[uint][]models.DMInputScore{group.ProjectDMID: groupScores}
+	groupInput.directWeights = map[uint][]models.DMInputDirectWeight{group.ProjectDMID: groupDirectWeights(input)}
+
+	if !input.quiet {
+		log.Printf("=== Menghitung ranking kelompok %s ===", mode)
+	}
+	ranks, err := s.rankDM(&groupInput, group)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregation := &calculations.AggregationResult{Method: mode}
+	for _, r := range ranks {
+		aggregation.Ranks = append(aggregation.Ranks, calculations.AlternativeRank{
+			AlternativeID: r.AlternativeID,
+			Rank:          r.Rank,
+			Score:         r.FinalScore,
+			Tied:          r.Tied,
+		})
+	}
+	return &calculationOutput{aggregation: aggregation}, nil
+}
+
+// groupDirectWeights averages the DMs' local direct weights by GroupWeight; sibling sums stay 1
+func groupDirectWeights(input *calculationInput) []models.DMInputDirectWeight {
+	if !usesDirectWeights(input.project.WeightingMode) {
+		return nil
+	}
+	sums := make(map[uint]float64)
+	weightSums := make(map[uint]float64)
+	for _, dm := range input.assignments {
+		weight := dm.GroupWeight
+		if weight == 0 {
+			weight = 1
+		}
+		for _, w := range input.directWeights[dm.ProjectDMID] {
+			sums[w.CriteriaID] += weight * w.WeightValue
+			weightSums[w.CriteriaID] += weight
+		}
+	}
+
+	var averaged []models.DMInputDirectWeight
+	for _, c := range input.criteria {
+		if weightSums[c.CriteriaID] > 0 {
+			averaged = append(averaged, models.DMInputDirectWeight{
+				CriteriaID:  c.CriteriaID,
+				WeightValue: sums[c.CriteriaID] / weightSums[c.CriteriaID],
+			})
+		}
+	}
+	return averaged
+}
+
+// rankDM dispatches the DM to the individual decision method of their assignment
+func (s *decisionService) rankDM(input *calculationInput, dm models.ProjectDecisionMaker) ([]calculations.TOPSISRank, error) {
+	methodName := dm.Method
+	if methodName == "" {
+		methodName = calculations.MethodTOPSIS
+	}
+	method, ok := s.methods.Get(methodName)
+	if !ok {
+		return nil, fmt.Errorf("decision method %s is not supported", methodName)
+	}
+
+	if !input.quiet {
+		log.Printf("Menghitung %s untuk DM: %d", method.Name(), dm.ProjectDMID)
+	}
+
+	// Hanya kriteria daun yang dinilai; bobotnya adalah bobot global hasil propagasi
+	leaves := calculations.LeafCriteria(input.criteria)
+
+	var weights map[uint]float64
+	if methodName == calculations.MethodAHP {
+		// AHP memakai bobot dari matriks perbandingan berpasangan DM
+		ahpWeights, err := s.ahpWeights(input.criteria, input.pairwise[dm.ProjectDMID])
+		if err != nil {
+			return nil, fmt.Errorf("DM %d: %v", dm.ProjectDMID, err)
+		}
+		weights = ahpWeights
+	} else {
+		resolved, err := s.resolveWeights(input, dm, leaves)
+		if err != nil {
+			return nil, fmt.Errorf("DM %d: %v", dm.ProjectDMID, err)
+		}
+		weights = resolved
+	}
+	if input.weightShift != nil {
+		weights = calculations.ShiftWeight(weights, leaves, input.weightShift.criteriaID, input.weightShift.weight)
+	}
+	if excluded := input.excludedCriteria[dm.ProjectDMID]; len(excluded) > 0 {
+		leaves, weights = withoutCriteria(leaves, weights, excluded)
+		if len(leaves) == 0 {
+			return nil, fmt.Errorf("DM %d: tidak ada kriteria dengan skor lengkap", dm.ProjectDMID)
+		}
+	}
+	if input.usedWeights != nil {
+		input.usedWeights[dm.ProjectDMID] = weights
+	}
+
+	options := calculations.RankingOptions{
+		Normalization: input.project.Normalization,
+		Ties:          tieOptions(&input.project),
+	}
+	var ranks []calculations.TOPSISRank
+	var topsisTrace *calculations.TOPSISTrace
+	var wpVectors *calculations.WPResult
+	var err error
+	topsis, isTOPSIS := method.(calculations.TOPSISCalculator)
+	wp, isWP := method.(calculations.WPCalculator)
+	switch {
+	case isTOPSIS && input.traces != nil:
+		ranks, topsisTrace, err = topsis.CalculateWithTrace(input.scores[dm.ProjectDMID], leaves, input.alternatives, weights, options)
+	case isWP && input.traces != nil:
+		// Vektor S dan V ikut disimpan di trace
+		wpVectors, err = wp.CalculateVectors(input.scores[dm.ProjectDMID], leaves, input.alternatives, weights, options)
+		if err == nil {
+			ranks = wpVectors.Ranks
+		}
+	default:
+		ranks, err = method.CalculateRanking(input.scores[dm.ProjectDMID], leaves, input.alternatives, weights, options)
+	}
+	if err != nil {
+		log.Printf("Error menghitung %s untuk DM %d: %v", method.Name(), dm.ProjectDMID, err)
+		return nil, err
+	}
+	if input.traces != nil {
+		input.traces[dm.ProjectDMID] = newDMTrace(dm.ProjectDMID, method.Name(), weights, topsisTrace, wpVectors, ranks)
+	}
+	return ranks, nil
+}
+
+// tieOptions reads the project's tie handling settings
+func tieOptions(project *models.DecisionProject) calculations.TieOptions {
+	return calculations.TieOptions{
+		Policy:    project.TiePolicy,
+		BreakRule: project.TieBreakRule,
+	}
+}
+
+// missingScorePolicy reads the project's missing score policy (BLOCK by default)
+func missingScorePolicy(project *models.DecisionProject) string {
+	if project.MissingScorePolicy == "" {
+		return calculations.MissingScoreBlock
+	}
+	return project.MissingScorePolicy
+}
+
+// applyMissingScorePolicy returns the input the methods rank. IMPUTE_* fills the empty cells of every DM,
+// EXCLUDE_DM marks the criteria each DM left incomplete and drops their scores on them. BLOCK changes
+// nothing here; validateCalculationInput refuses incomplete inputs before the calculation starts.
+func applyMissingScorePolicy(input *calculationInput) (*calculationInput, error) {
+	policy := missingScorePolicy(&input.project)
+	if policy == calculations.MissingScoreBlock {
+		return input, nil
+	}
+
+	leaves := calculations.LeafCriteria(input.criteria)
+	var pool [][]models.DMInputScore
+	for _, dm := range input.assignments {
+		pool = append(pool, input.scores[dm.ProjectDMID])
+	}
+
+	prepared := *input
+	prepared.scores = make(map[uint][]models.DMInputScore)
+	prepared.excludedCriteria = make(map[uint]map[uint]bool)
+	for _, dm := range input.assignments {
+		scores := input.scores[dm.ProjectDMID]
+		if policy != calculations.MissingScoreExcludeDM {
+			imputed, err := calculations.ImputeScores(scores, pool, leaves, input.alternatives, policy)
+			if err != nil {
+				return nil, fmt.Errorf("DM %d: %v", dm.ProjectDMID, err)
+			}
+			prepared.scores[dm.ProjectDMID] = imputed
+			continue
+		}
+
+		excluded := make(map[uint]bool)
+		for _, cell := range calculations.MissingScores(scores, leaves, input.alternatives) {
+			excluded[cell.CriteriaID] = true
+		}
+		var kept []models.DMInputScore
+		for _, sc := range scores {
+			if !excluded[sc.CriteriaID] {
+				kept = append(kept, sc)
+			}
+		}
+		prepared.scores[dm.ProjectDMID] = kept
+		if len(excluded) > 0 {
+			prepared.excludedCriteria[dm.ProjectDMID] = excluded
+		}
+	}
+
+	// Pada mode AIJ setiap sel matriks kelompok membutuhkan minimal satu DM
+	if policy == calculations.MissingScoreExcludeDM && calculations.IsAIJ(input.project.GroupMode) {
+		for _, c := range leaves {
+			covered := false
+			for _, dm := range input.assignments {
+				if !prepared.excludedCriteria[dm.ProjectDMID][c.CriteriaID] {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				return nil, fmt.Errorf("tidak ada Decision Maker dengan skor lengkap untuk kriteria %s", c.Name)
+			}
+		}
+	}
+	return &prepared, nil
+}
+
+// withoutCriteria drops the excluded leaves and rescales the remaining weights to sum to 1
+func withoutCriteria(leaves []models.Criteria, weights map[uint]float64, excluded map[uint]bool) ([]models.Criteria, map[uint]float64) {
+	var kept []models.Criteria
+	sum := 0.0
+	for _, c := range leaves {
+		if !excluded[c.CriteriaID] {
+			kept = append(kept, c)
+			sum += weights[c.CriteriaID]
+		}
+	}
+	rescaled := make(map[uint]float64)
+	for _, c := range kept {
+		if sum > 0 {
+			rescaled[c.CriteriaID] = weights[c.CriteriaID] / sum
+		}
+	}
+	return kept, rescaled
+}
+
+// usesDirectWeights reports whether the weighting mode reads the DMs' direct weights
+func usesDirectWeights(mode string) bool {
+	return mode == weightingDMDirect || mode == weightingBlend
+}
+
+// usesAdminWeights reports whether the weighting mode reads Criteria.Weight
+func usesAdminWeights(mode string) bool {
+	return mode == "" || mode == weightingAdmin || mode == weightingBlend
+}
+
+// objectiveWeighting splits an objective weighting mode into the weighting name and whether it pools all DMs
+func objectiveWeighting(mode string) (name string, pooled bool, ok bool) {
+	if usesAdminWeights(mode) || usesDirectWeights(mode) {
+		return "", false, false
+	}
+	name = strings.TrimSuffix(mode, pooledWeightingSuffix)
+	return name, name != mode, true
+}
+
+// resolveWeights builds the global criteria weights of one DM according to the project's weighting mode:
+// ADMIN uses Criteria.Weight, DM_DIRECT the DM's own direct weights and BLEND mixes both with WeightBlend.
+// Weights are entered locally per group of siblings and propagated down the criteria tree.
+// Objective modes compute the leaf weights straight from the scores.
+func (s *decisionService) resolveWeights(input *calculationInput, dm models.ProjectDecisionMaker, leaves []models.Criteria) (map[uint]float64, error) {
+	if name, pooled, ok := objectiveWeighting(input.project.WeightingMode); ok {
+		weighting, found := s.weightings.Get(name)
+		if !found {
+			return nil, fmt.Errorf("weighting mode %s is not supported", input.project.WeightingMode)
+		}
+
+		scoreSets := [][]models.DMInputScore{input.scores[dm.ProjectDMID]}
+		if pooled {
+			scoreSets = scoreSets[:0]
+			for _, a := range input.assignments {
+				scoreSets = append(scoreSets, input.scores[a.ProjectDMID])
+			}
+		}
+		return weighting.CalculateWeights(scoreSets, leaves, input.alternatives)
+	}
+
+	adminLocal := make(map[uint]float64)
+	for _, c := range input.criteria {
+		adminLocal[c.CriteriaID] = c.Weight
+	}
+	adminWeights := calculations.PropagateWeights(input.criteria, adminLocal)
+	if !usesDirectWeights(input.project.WeightingMode) {
+		return adminWeights, nil
+	}
+
+	dmLocal := make(map[uint]float64)
+	for _, w := range input.directWeights[dm.ProjectDMID] {
+		dmLocal[w.CriteriaID] = w.WeightValue
+	}
+	dmWeights := calculations.PropagateWeights(input.criteria, dmLocal)
+	if input.project.WeightingMode == weightingDMDirect {
+		return dmWeights, nil
+	}
+
+	alpha := input.project.WeightBlend
+	blended := make(map[uint]float64)
+	for _, c := range input.criteria {
+		blended[c.CriteriaID] = alpha*adminWeights[c.CriteriaID] + (1-alpha)*dmWeights[c.CriteriaID]
+	}
+	return blended, nil
+}
+
+// ahpWeights derives global criteria weights from a DM's pairwise comparisons:
+// the local priority of each criterion is multiplied by the weight of its parent.
+func (s *decisionService) ahpWeights(criteria []models.Criteria, comparisons []models.DMInputPairwise) (map[uint]float64, error) {
+	result, err := buildPairwiseResult(s.ahpCalc, criteria, comparisons, calculations.AHPMethodEigenvector)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Consistent {
+		return nil, errors.New(inconsistentPairwiseMessage)
+	}
+
+	local := make(map[uint]float64)
+	for _, group := range result.Groups {
+		for id, p := range group.Priorities {
+			local[id] = p
+		}
+	}
+
+	// Kriteria tanpa saudara tidak perlu dibandingkan
+	siblingCount := make(map[uint]int)
+	for _, c := range criteria {
+		siblingCount[parentKey(c.ParentCriteriaID)]++
+	}
+	for _, c := range criteria {
+		if _, ok := local[c.CriteriaID]; ok {
+			continue
+		}
+		if siblingCount[parentKey(c.ParentCriteriaID)] > 1 {
+			return nil, fmt.Errorf("perbandingan berpasangan untuk kriteria %s belum lengkap", c.Name)
+		}
+		local[c.CriteriaID] = 1
+	}
+
+	return calculations.PropagateWeights(criteria, local), nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"encoding/json"
+	"services/internal/calculations"
+	"services/internal/models"
+)
+
+// newCalculationRun records the settings, DM methods and leaf weights the calculation ran with,
+// the input snapshot and the hashes of the inputs and results
+func newCalculationRun(input *calculationInput, output *calculationOutput, adminID uint) (*models.CalculationRun, error) {
+	project := &input.project
+	snapshot, inputHash, err := newInputSnapshot(input)
+	if err != nil {
+		return nil, err
+	}
+	outputHash, err := resultHash(output)
+	if err != nil {
+		return nil, err
+	}
+
+	methods := make(map[uint]string)
+	for id, t := range input.traces {
+		methods[id] = t.Method
+	}
+	methodsJSON, err := json.Marshal(methods)
+	if err != nil {
+		return nil, err
+	}
+	weightsJSON, err := json.Marshal(input.usedWeights)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregation := project.AggregationMethod
+	if aggregation == "" {
+		aggregation = calculations.AggregationBorda
+	}
+	return &models.CalculationRun{
+		ProjectID:          project.ProjectID,
+		RoundNumber:        currentRound(project),
+		TriggeredByAdminID: adminID,
+		AggregationMethod:  aggregation,
+		GroupMode:          project.GroupMode,
+		WeightingMode:      project.WeightingMode,
+		Normalization:      topsisNormalization(project),
+		TiePolicy:          project.TiePolicy,
+		TieBreakRule:       project.TieBreakRule,
+		MissingScorePolicy: missingScorePolicy(project),
+		Methods:            string(methodsJSON),
+		Weights:            string(weightsJSON),
+		InputSnapshot:      snapshot,
+		InputHash:          inputHash,
+		ResultHash:         outputHash,
+	}, nil
+}
+
+// jsonOrNull keeps an empty JSON column valid in the response
+func jsonOrNull(value string) json.RawMessage {
+	if value == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(value)
+}
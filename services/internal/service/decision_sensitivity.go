@@ -0,0 +1,281 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"math"
+	"services/internal/calculations"
+	"services/internal/models"
+)
+
+// Defaults of the weight sensitivity analysis
+const (
+	defaultSensitivitySteps = 20
+	// sensitivityTolerance is how precisely a rank reversal point is located
+	sensitivityTolerance = 1e-6
+)
+
+// rankingSnapshot is the final ranking of one calculation run
+type rankingSnapshot struct {
+	ids   []uint
+	ranks []int
+}
+
+func snapshotOf(aggregation *calculations.AggregationResult) rankingSnapshot {
+	var snap rankingSnapshot
+	for _, r := range aggregation.Ranks {
+		snap.ids = append(snap.ids, r.AlternativeID)
+		snap.ranks = append(snap.ranks, r.Rank)
+	}
+	return snap
+}
+
+// same reports whether the first k positions of both rankings are identical; k <= 0 compares all of them
+func (a rankingSnapshot) same(b rankingSnapshot, k int) bool {
+	if k <= 0 || k > len(a.ids) {
+		k = len(a.ids)
+	}
+	if len(b.ids) < k || (k == len(a.ids) && len(a.ids) != len(b.ids)) {
+		return false
+	}
+	for i := 0; i < k; i++ {
+		if a.ids[i] != b.ids[i] || a.ranks[i] != b.ranks[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (a rankingSnapshot) top(k int) []uint {
+	if k > len(a.ids) {
+		k = len(a.ids)
+	}
+	return append([]uint(nil), a.ids[:k]...)
+}
+
+// AnalyzeSensitivity varies the weight of each leaf criterion over a range, rescales the other
+// weights proportionally and re-runs the configured methods and aggregation. Nothing is stored.
+func (s *decisionService) AnalyzeSensitivity(projectID uint, companyID uint, req models.SensitivityInput) (*models.SensitivityDTO, error) {
+	project, err := s.checkProjectAccess(projectID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	topK, steps, minWeight, maxWeight := req.TopK, req.Steps, 0.0, 1.0
+	if topK == 0 {
+		topK = 1
+	}
+	if steps == 0 {
+		steps = defaultSensitivitySteps
+	}
+	if req.MinWeight != nil {
+		minWeight = *req.MinWeight
+	}
+	if req.MaxWeight != nil {
+		maxWeight = *req.MaxWeight
+	}
+	if minWeight >= maxWeight {
+		return nil, errors.New("min_weight must be lower than max_weight")
+	}
+
+	input, err := s.loadCalculationInput(project)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCalculationInput(input); err != nil {
+		return nil, err
+	}
+
+	leaves := calculations.LeafCriteria(input.criteria)
+	if len(leaves) < 2 {
+		return nil, errors.New("sensitivity analysis needs at least two leaf criteria")
+	}
+	targets := leaves
+	if len(req.CriteriaIDs) > 0 {
+		leafMap := make(map[uint]models.Criteria)
+		for _, c := range leaves {
+			leafMap[c.CriteriaID] = c
+		}
+		targets = nil
+		for _, id := range req.CriteriaIDs {
+			c, ok := leafMap[id]
+			if !ok {
+				return nil, errors.New("criteria_ids must reference leaf criteria of this project")
+			}
+			targets = append(targets, c)
+		}
+	}
+
+	// Ratusan perhitungan ulang: log progres pipeline dimatikan
+	input.quiet = true
+
+	// Hasil dasar dengan bobot apa adanya
+	input.usedWeights = make(map[uint]map[uint]float64)
+	output, err := s.runCalculation(input)
+	if err != nil {
+		return nil, err
+	}
+	base := snapshotOf(output.aggregation)
+	baseWeights := averageUsedWeights(input)
+	input.usedWeights = nil
+	if topK > len(base.ids) {
+		topK = len(base.ids)
+	}
+
+	result := &models.SensitivityDTO{
+		ProjectID:   projectID,
+		RoundNumber: currentRound(project),
+		TopK:        topK,
+		MinWeight:   minWeight,
+		MaxWeight:   maxWeight,
+		Steps:       steps,
+		BaseRanking: base.ids,
+	}
+
+	for _, c := range targets {
+		log.Printf("[Sensitivitas] Kriteria %d (%s), bobot dasar %.4f", c.CriteriaID, c.Name, baseWeights[c.CriteriaID])
+		criterion, err := s.analyzeCriterion(input, c, baseWeights[c.CriteriaID], base, topK, steps, minWeight, maxWeight)
+		if err != nil {
+			return nil, err
+		}
+		result.Criteria = append(result.Criteria, *criterion)
+	}
+	return result, nil
+}
+
+// analyzeCriterion scans the weight range on a grid and bisects every grid cell in which the ranking changes
+func (s *decisionService) analyzeCriterion(
+	input *calculationInput,
+	c models.Criteria,
+	baseWeight float64,
+	base rankingSnapshot,
+	topK, steps int,
+	minWeight, maxWeight float64,
+) (*models.CriterionSensitivityDTO, error) {
+	rankAt := func(weight float64) (rankingSnapshot, error) {
+		shifted := *input
+		shifted.weightShift = &weightShift{criteriaID: c.CriteriaID, weight: weight}
+		output, err := s.runCalculation(&shifted)
+		if err != nil {
+			return rankingSnapshot{}, err
+		}
+		return snapshotOf(output.aggregation), nil
+	}
+
+	criterion := &models.CriterionSensitivityDTO{
+		CriteriaID: c.CriteriaID,
+		Name:       c.Name,
+		BaseWeight: baseWeight,
+	}
+
+	prevWeight := minWeight
+	prev, err := rankAt(prevWeight)
+	if err != nil {
+		return nil, err
+	}
+	interval := models.StableIntervalDTO{From: minWeight, TopK: prev.top(topK)}
+
+	for i := 1; i <= steps; i++ {
+		weight := minWeight + (maxWeight-minWeight)*float64(i)/float64(steps)
+		current, err := rankAt(weight)
+		if err != nil {
+			return nil, err
+		}
+		if current.same(prev, 0) {
+			prevWeight, prev = weight, current
+			continue
+		}
+
+		// Bagi dua sampai titik perubahan ranking cukup presisi
+		before, after := prev, current
+		left, right, err := calculations.BisectReversal(prevWeight, weight, sensitivityTolerance, func(mid float64) (bool, error) {
+			snap, err := rankAt(mid)
+			if err != nil {
+				return false, err
+			}
+			if snap.same(before, 0) {
+				return false, nil
+			}
+			after = snap
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		point := (left + right) / 2
+		changesTopK := !after.same(before, topK)
+		criterion.Reversals = append(criterion.Reversals, models.RankReversalDTO{
+			Weight:        point,
+			Delta:         point - baseWeight,
+			RankingBefore: before.ids,
+			RankingAfter:  after.ids,
+			ChangesTopK:   changesTopK,
+		})
+		if changesTopK {
+			interval.To = point
+			criterion.Intervals = append(criterion.Intervals, interval)
+			interval = models.StableIntervalDTO{From: point, TopK: after.top(topK)}
+
+			delta := math.Abs(point - baseWeight)
+			if criterion.MinChangeToFlip == nil || delta < *criterion.MinChangeToFlip {
+				criterion.MinChangeToFlip = &delta
+			}
+		}
+
+		// Sel grid yang sama bisa memuat perubahan lain setelah titik ini
+		if !after.same(current, 0) {
+			i--
+		}
+		prevWeight, prev = right, after
+	}
+	interval.To = maxWeight
+	criterion.Intervals = append(criterion.Intervals, interval)
+
+	baseTop := base.top(topK)
+	for i := range criterion.Intervals {
+		iv := criterion.Intervals[i]
+		if baseWeight >= iv.From && baseWeight <= iv.To && sameIDs(iv.TopK, baseTop) {
+			criterion.BaseInterval = &iv
+			break
+		}
+	}
+	return criterion, nil
+}
+
+// averageUsedWeights averages the leaf weights recorded during a run by the DMs' GroupWeight
+func averageUsedWeights(input *calculationInput) map[uint]float64 {
+	groupWeights := make(map[uint]float64)
+	for _, dm := range input.assignments {
+		groupWeights[dm.ProjectDMID] = dm.GroupWeight
+	}
+
+	sums := make(map[uint]float64)
+	total := 0.0
+	for dmID, weights := range input.usedWeights {
+		weight := groupWeights[dmID]
+		if weight == 0 {
+			weight = 1
+		}
+		for id, w := range weights {
+			sums[id] += weight * w
+		}
+		total += weight
+	}
+	for id := range sums {
+		sums[id] /= total
+	}
+	return sums
+}
+
+func sameIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
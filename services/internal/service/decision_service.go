@@ -2,15 +2,28 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"services/internal/calculations"
 	"services/internal/models"
 	"services/internal/repository"
+	"sort"
+
+	"gorm.io/gorm"
 )
 
 type DecisionService interface {
-	CalculateResults(projectID uint, companyID uint, role string) error
-	GetResults(projectID uint, companyID uint) ([]models.ResultRanking, error)
+	CalculateResults(projectID uint, companyID uint, adminID uint, role string) (*calculations.AggregationResult, *models.CalculationRun, error)
+	GetResults(projectID uint, companyID uint, roundNumber int, runID uint) ([]models.ResultRanking, error)
+	GetRuns(projectID uint, companyID uint) ([]models.CalculationRunDTO, error)
+	GetRunSnapshot(projectID uint, companyID uint, runID uint) (*models.RunSnapshotDTO, error)
+	ReplayRun(projectID uint, companyID uint, runID uint) (*models.RunReplayDTO, error)
+	GetConsensus(projectID uint, companyID uint, roundNumber int) (*models.ConsensusDTO, error)
+	GetScoreCompleteness(projectID uint, companyID uint, roundNumber int) (*models.ScoreCompletenessDTO, error)
+	AnalyzeSensitivity(projectID uint, companyID uint, input models.SensitivityInput) (*models.SensitivityDTO, error)
+	AnalyzeSMAA(projectID uint, companyID uint, input models.SMAAInput) (*models.SMAADTO, error)
+	Simulate(projectID uint, companyID uint, role string, input models.SimulateInput) (*models.SimulationDTO, error)
+	GetTrace(projectID uint, companyID uint, roundNumber int, runID uint) (*models.CalculationTraceDTO, error)
 }
 
 type decisionService struct {
@@ -21,9 +34,14 @@ type decisionService struct {
 	directWtRepo  repository.InputDirectWeightRepository
 	scoreRepo     repository.InputScoreRepository
 	resultRepo    repository.ResultRankingRepository
-
-	topsisCalc calculations.TOPSISCalculator
-	bordaCalc  calculations.BordaCalculator
+	pairwiseRepo  repository.InputPairwiseRepository
+	traceRepo     repository.CalculationTraceRepository
+	runRepo       repository.CalculationRunRepository
+
+	methods     calculations.MethodRegistry
+	ahpCalc     calculations.AHPCalculator
+	aggregators calculations.AggregatorRegistry
+	weightings  calculations.WeightingRegistry
 }
 
 func NewDecisionService(
@@ -34,8 +52,13 @@ func NewDecisionService(
 	dwRepo repository.InputDirectWeightRepository,
 	sRepo repository.InputScoreRepository,
 	rRepo repository.ResultRankingRepository,
-	topsis calculations.TOPSISCalculator,
-	borda calculations.BordaCalculator,
+	pwRepo repository.InputPairwiseRepository,
+	tRepo repository.CalculationTraceRepository,
+	runRepo repository.CalculationRunRepository,
+	methods calculations.MethodRegistry,
+	ahp calculations.AHPCalculator,
+	aggregators calculations.AggregatorRegistry,
+	weightings calculations.WeightingRegistry,
 ) DecisionService {
 	return &decisionService{
 		projectRepo:   pRepo,
@@ -45,213 +68,392 @@ func NewDecisionService(
 		directWtRepo:  dwRepo,
 		scoreRepo:     sRepo,
 		resultRepo:    rRepo,
-		topsisCalc:    topsis,
-		bordaCalc:     borda,
+		pairwiseRepo:  pwRepo,
+		traceRepo:     tRepo,
+		runRepo:       runRepo,
+		methods:       methods,
+		ahpCalc:       ahp,
+		aggregators:   aggregators,
+		weightings:    weightings,
 	}
 }
 
-func (s *decisionService) checkProjectAccess(projectID uint, companyID uint) error {
+func (s *decisionService) checkProjectAccess(projectID uint, companyID uint) (*models.DecisionProject, error) {
 	project, err := s.projectRepo.GetProjectByID(projectID, companyID)
 	if err != nil {
-		return errors.New("project not found or user does not have access")
+		return nil, errors.New("project not found or user does not have access")
 	}
 	if project == nil {
-		return errors.New("project not found")
+		return nil, errors.New("project not found")
 	}
-	return nil
+	return project, nil
 }
 
-func (s *decisionService) validateProjectReadyForCalculation(projectID uint) error {
-	// 1. Check criteria
-	allCriteria, err := s.criteriaRepo.GetCriteriaByProjectID(projectID)
-	if err != nil {
-		return err
+// resolveAggregator picks the group aggregator configured on the project (BORDA by default)
+func (s *decisionService) resolveAggregator(project *models.DecisionProject) (calculations.GroupAggregator, error) {
+	method := project.AggregationMethod
+	if method == "" {
+		method = calculations.AggregationBorda
+	}
+	aggregator, ok := s.aggregators.Get(method)
+	if !ok {
+		return nil, fmt.Errorf("aggregation method %s is not supported", method)
 	}
-	if len(allCriteria) == 0 {
+	return aggregator, nil
+}
+
+// validateCalculationInput checks that the loaded inputs are complete enough to calculate
+func validateCalculationInput(input *calculationInput) error {
+	project := &input.project
+
+	// 1. Check criteria
+	if len(input.criteria) == 0 {
 		return errors.New("Proyek belum memiliki kriteria. Silakan tambahkan kriteria terlebih dahulu.")
 	}
 
 	// 2. Check alternatives
-	alternatives, err := s.altRepo.GetAlternativeByProject(projectID)
-	if err != nil {
-		return err
-	}
-	if len(alternatives) == 0 {
+	if len(input.alternatives) == 0 {
 		return errors.New("Proyek belum memiliki alternatif (kandidat). Silakan tambahkan kandidat terlebih dahulu.")
 	}
 
 	// 3. Check DM assignments
-	assignments, err := s.projectDMRepo.GetAssignmentsByProjectID(projectID)
-	if err != nil {
-		return err
-	}
-	if len(assignments) == 0 {
+	if len(input.assignments) == 0 {
 		return errors.New("Belum ada Decision Maker yang ditugaskan untuk proyek ini.")
 	}
 
 	// 4. Check criteria weights (Admin input)
-	for _, c := range allCriteria {
-		if c.Weight == 0 {
-			return errors.New("Ada kriteria yang belum memiliki bobot. Silakan lengkapi bobot untuk semua kriteria.")
+	if usesAdminWeights(project.WeightingMode) {
+		adminLocal := make(map[uint]float64)
+		for _, c := range input.criteria {
+			if c.Weight == 0 {
+				return errors.New("Ada kriteria yang belum memiliki bobot. Silakan lengkapi bobot untuk semua kriteria.")
+			}
+			adminLocal[c.CriteriaID] = c.Weight
 		}
+		if err := calculations.ValidateSiblingWeights(input.criteria, adminLocal, weightSumTolerance); err != nil {
+			return err
+		}
+	}
+
+	criteriaByID := make(map[uint]*models.Criteria)
+	for i, c := range input.criteria {
+		criteriaByID[c.CriteriaID] = &input.criteria[i]
 	}
 
 	// 5. Check DM input data
-	for _, dm := range assignments {
-		scores, _ := s.scoreRepo.GetScores(dm.ProjectDMID)
-		if len(scores) == 0 {
+	for _, dm := range input.assignments {
+		if len(input.scores[dm.ProjectDMID]) == 0 {
 			return errors.New("Decision Maker belum melengkapi input skor untuk kandidat.")
 		}
+		if missingScorePolicy(project) == calculations.MissingScoreBlock {
+			missing := calculations.MissingScores(input.scores[dm.ProjectDMID], calculations.LeafCriteria(input.criteria), input.alternatives)
+			if len(missing) > 0 {
+				return fmt.Errorf("Decision Maker %d belum melengkapi %d skor. Periksa kelengkapan skor untuk daftar sel yang kosong.", dm.ProjectDMID, len(missing))
+			}
+		}
+		// Skor lama bisa berada di luar skala bila skala kriteria diubah setelah DM menilai
+		for _, sc := range input.scores[dm.ProjectDMID] {
+			if criteria, ok := criteriaByID[sc.CriteriaID]; ok {
+				if err := validateScore(criteria, sc.ScoreValue); err != nil {
+					return fmt.Errorf("%v (Decision Maker %d)", err, dm.ProjectDMID)
+				}
+			}
+		}
+
+		// 6. DM dengan metode AHP wajib mengisi perbandingan berpasangan (tidak dipakai pada mode AIJ)
+		if dm.Method == calculations.MethodAHP && !calculations.IsAIJ(project.GroupMode) {
+			if len(input.pairwise[dm.ProjectDMID]) == 0 {
+				return errors.New("Decision Maker dengan metode AHP belum melengkapi perbandingan berpasangan kriteria.")
+			}
+			continue
+		}
+
+		// 7. Bobot langsung DM harus mencakup semua kriteria dan ternormalisasi
+		if usesDirectWeights(project.WeightingMode) {
+			weights := input.directWeights[dm.ProjectDMID]
+			if len(weights) == 0 {
+				return errors.New("Decision Maker belum melengkapi input bobot langsung kriteria.")
+			}
+			if err := validateDirectWeights(input.criteria, weights); err != nil {
+				return fmt.Errorf("Decision Maker %d: %v", dm.ProjectDMID, err)
+			}
+		}
 	}
 
 	return nil
 }
 
-func (s *decisionService) GetResults(projectID uint, companyID uint) ([]models.ResultRanking, error) {
-	if err := s.checkProjectAccess(projectID, companyID); err != nil {
+// topsisNormalization is the normalization TOPSIS applies for the project
+func topsisNormalization(project *models.DecisionProject) string {
+	if project.Normalization == "" {
+		return calculations.NormalizationVector
+	}
+	return project.Normalization
+}
+
+// resolveRun picks the requested run, or the latest run of the round (0 = current) when runID is 0.
+// It returns nil without error when the round has not been calculated yet.
+func (s *decisionService) resolveRun(project *models.DecisionProject, roundNumber int, runID uint) (*models.CalculationRun, error) {
+	if runID != 0 {
+		run, err := s.runRepo.GetRun(project.ProjectID, runID)
+		if err != nil {
+			return nil, errors.New("calculation run not found")
+		}
+		return run, nil
+	}
+	if roundNumber == 0 {
+		roundNumber = currentRound(project)
+	}
+	run, err := s.runRepo.GetLatestRun(project.ProjectID, roundNumber)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
 		return nil, err
 	}
-	return s.resultRepo.GetRangkings(projectID)
+	return run, nil
 }
 
-func (s *decisionService) CalculateResults(projectID uint, companyID uint, role string) error {
-	if role != "admin" {
-		return errors.New("only admins can trigger calculation")
+// GetResults returns the results of a run; without a run it is the latest run of the round (0 = current)
+func (s *decisionService) GetResults(projectID uint, companyID uint, roundNumber int, runID uint) ([]models.ResultRanking, error) {
+	project, err := s.checkProjectAccess(projectID, companyID)
+	if err != nil {
+		return nil, err
 	}
-	if err := s.checkProjectAccess(projectID, companyID); err != nil {
-		return err
+	run, err := s.resolveRun(project, roundNumber, runID)
+	if err != nil {
+		return nil, err
 	}
+	if run == nil {
+		return []models.ResultRanking{}, nil
+	}
+	return s.resultRepo.GetRankingsByRun(run.RunID)
+}
 
-	// Validate project has all required data
-	if err := s.validateProjectReadyForCalculation(projectID); err != nil {
-		return err
+// GetRuns lists the calculation runs of the project, newest first
+func (s *decisionService) GetRuns(projectID uint, companyID uint) ([]models.CalculationRunDTO, error) {
+	if _, err := s.checkProjectAccess(projectID, companyID); err != nil {
+		return nil, err
+	}
+	runs, err := s.runRepo.GetRuns(projectID)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("Memulai kalkulasi untuk Proyek ID: %d", projectID)
+	runDTOs := []models.CalculationRunDTO{}
+	for _, r := range runs {
+		runDTOs = append(runDTOs, models.CalculationRunDTO{
+			RunID:              r.RunID,
+			ProjectID:          r.ProjectID,
+			RoundNumber:        r.RoundNumber,
+			TriggeredByAdminID: r.TriggeredByAdminID,
+			AggregationMethod:  r.AggregationMethod,
+			GroupMode:          r.GroupMode,
+			WeightingMode:      r.WeightingMode,
+			Normalization:      r.Normalization,
+			TiePolicy:          r.TiePolicy,
+			TieBreakRule:       r.TieBreakRule,
+			MissingScorePolicy: r.MissingScorePolicy,
+			Methods:            jsonOrNull(r.Methods),
+			Weights:            jsonOrNull(r.Weights),
+			InputHash:          r.InputHash,
+			ResultHash:         r.ResultHash,
+			CreatedAt:          r.CreatedAt,
+		})
+	}
+	return runDTOs, nil
+}
 
-	// Clear existing results
-	if err := s.resultRepo.ClearRangkings(projectID); err != nil {
-		log.Printf("Error menghapus hasil lama: %v", err)
-		return err
+func (s *decisionService) CalculateResults(projectID uint, companyID uint, adminID uint, role string) (*calculations.AggregationResult, *models.CalculationRun, error) {
+	if role != "admin" {
+		return nil, nil, errors.New("only admins can trigger calculation")
+	}
+	project, err := s.checkProjectAccess(projectID, companyID)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Get all required data
-	assignments, err := s.projectDMRepo.GetAssignmentsByProjectID(projectID)
+	input, err := s.loadCalculationInput(project)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	allCriteria, err := s.criteriaRepo.GetCriteriaByProjectID(projectID)
-	if err != nil {
-		return err
+	// Validate project has all required data
+	if err := validateCalculationInput(input); err != nil {
+		return nil, nil, err
 	}
 
-	alternatives, err := s.altRepo.GetAlternativeByProject(projectID)
+	log.Printf("Memulai kalkulasi untuk Proyek ID: %d", projectID)
+	input.traces = make(map[uint]*dmTrace)
+	input.usedWeights = make(map[uint]map[uint]float64)
+
+	output, err := s.runCalculation(input)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Buat map untuk nama alternatif
 	altMap := make(map[uint]string)
-	for _, a := range alternatives {
+	for _, a := range input.alternatives {
 		altMap[a.AlternativeID] = a.Name
 	}
 
-	// Step 1: Calculate TOPSIS for each DM
-	var allDMRankings []calculations.SingleDMRanking
 	var allResultsToSave []models.ResultRanking
 
-	for _, dm := range assignments {
-		log.Printf("Menghitung TOPSIS untuk DM: %d", dm.ProjectDMID)
-
-		// Get scores from DM input
-		scoreData, err := s.scoreRepo.GetScores(dm.ProjectDMID)
-		if err != nil {
-			log.Printf("Error mendapatkan skor untuk DM %d: %v", dm.ProjectDMID, err)
-			return err
-		}
-
-		// Get weights from criteria (Admin input)
-		weights := make(map[uint]float64)
-		for _, c := range allCriteria {
-			weights[c.CriteriaID] = c.Weight
-		}
-
-		// Calculate TOPSIS for this DM
-		topsisRanks, err := s.topsisCalc.CalculateRanking(scoreData, allCriteria, alternatives, weights)
-		if err != nil {
-			log.Printf("Error menghitung TOPSIS untuk DM %d: %v", dm.ProjectDMID, err)
-			return err
+	// Step 1: Save individual results per DM
+	for _, dm := range output.dmResults {
+		projectDMID := dm.assignment.ProjectDMID
+		normalization := ""
+		if dm.assignment.Method == calculations.MethodTOPSIS || dm.assignment.Method == "" {
+			normalization = topsisNormalization(project)
 		}
+		for _, r := range dm.ranks {
+			log.Printf("  DM %d (%s): %s (ID:%d) = Rank %d, Score: %.6f",
+				projectDMID, dm.assignment.Method, altMap[r.AlternativeID], r.AlternativeID, r.Rank, r.FinalScore)
 
-		// Convert TOPSIS results to Borda format
-		var dmRanking calculations.SingleDMRanking
-		dmRanking.DMID = dm.ProjectDMID
-		dmRanking.DMWeight = dm.GroupWeight
-
-		// Pastikan kita memiliki semua alternatif dengan ranking
-		for i, r := range topsisRanks {
-			dmRanking.RankedList = append(dmRanking.RankedList, calculations.AlternativeRank{
-				AlternativeID: r.AlternativeID,
-				Rank:          i + 1, // Rank berdasarkan posisi di sorted list
-				Score:         r.FinalScore,
-			})
-
-			log.Printf("  DM %d: %s (ID:%d) = Rank %d, Score: %.6f",
-				dm.ProjectDMID, altMap[r.AlternativeID], r.AlternativeID, i+1, r.FinalScore)
-
-			// Save TOPSIS results per DM
 			allResultsToSave = append(allResultsToSave, models.ResultRanking{
 				ProjectID:     projectID,
 				AlternativeID: r.AlternativeID,
-				ProjectDMID:   &dm.ProjectDMID,
+				ProjectDMID:   &projectDMID,
+				RoundNumber:   currentRound(project),
 				FinalScore:    r.FinalScore,
-				Rank:          i + 1,
+				Rank:          r.Rank,
+				Normalization: normalization,
+				IsTied:        r.Tied,
 			})
 		}
-
-		// DEBUG: Pastikan semua alternatif ada
-		log.Printf("DM %d ranking count: %d", dm.ProjectDMID, len(dmRanking.RankedList))
-
-		allDMRankings = append(allDMRankings, dmRanking)
 	}
 
-	// DEBUG: Cek data yang akan dikirim ke Borda
-	log.Println("=== DATA UNTUK BORDA ===")
-	for i, dmRank := range allDMRankings {
-		log.Printf("DM %d (ID:%d, Weight:%.1f) - %d alternatif:",
-			i+1, dmRank.DMID, dmRank.DMWeight, len(dmRank.RankedList))
-		for _, altRank := range dmRank.RankedList {
-			log.Printf("  Alt %d: Rank %d, Score: %.6f",
-				altRank.AlternativeID, altRank.Rank, altRank.Score)
-		}
-	}
-
-	// Step 2: Calculate Borda aggregate
-	log.Println("=== Menghitung ranking final BORDA ===")
-	finalBordaRanks := s.bordaCalc.AggregateBorda(allDMRankings)
-
-	if len(finalBordaRanks) == 0 {
-		log.Println("ERROR: Borda calculator tidak menghasilkan ranking!")
-		return errors.New("gagal menghitung ranking Borda")
+	// Step 2: Save aggregate results; on AIJ these are the TOPSIS scores of the group matrix
+	aggregation := output.aggregation
+	finalNormalization := ""
+	if calculations.IsAIJ(project.GroupMode) {
+		finalNormalization = topsisNormalization(project)
 	}
-
-	// Step 3: Save Borda results
-	log.Println("=== HASIL BORDA FINAL ===")
-	for _, r := range finalBordaRanks {
+	log.Printf("=== HASIL %s FINAL ===", aggregation.Method)
+	for _, r := range aggregation.Ranks {
 		allResultsToSave = append(allResultsToSave, models.ResultRanking{
 			ProjectID:     projectID,
 			AlternativeID: r.AlternativeID,
 			ProjectDMID:   nil, // Nil untuk hasil aggregate
+			RoundNumber:   currentRound(project),
 			FinalScore:    r.Score,
 			Rank:          r.Rank,
+			Normalization: finalNormalization,
+			IsTied:        r.Tied,
 		})
 
 		log.Printf("Rank %d: %s (ID:%d) - Score: %.6f",
 			r.Rank, altMap[r.AlternativeID], r.AlternativeID, r.Score)
 	}
 
-	// Save all results to database
+	run, err := newCalculationRun(input, output, adminID)
+	if err != nil {
+		return nil, nil, err
+	}
+	trace, err := buildTrace(input, output)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Save the run, its results and trace at once; earlier runs are kept
 	log.Println("Menyimpan semua hasil ke database...")
-	return s.resultRepo.CreateRankings(allResultsToSave)
-}
\ No newline at end of file
+	if err := s.runRepo.CreateRun(run, allResultsToSave, trace); err != nil {
+		log.Printf("Error menyimpan run perhitungan: %v", err)
+		return nil, nil, err
+	}
+	log.Printf("Run perhitungan %d tersimpan", run.RunID)
+	return aggregation, run, nil
+}
+
+// GetConsensus measures how much the stored per-DM rankings of a round (0 = current) agree
+// with each other and with the final ranking
+func (s *decisionService) GetConsensus(projectID uint, companyID uint, roundNumber int) (*models.ConsensusDTO, error) {
+	project, err := s.checkProjectAccess(projectID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if calculations.IsAIJ(project.GroupMode) {
+		return nil, errors.New(aijConsensusMessage)
+	}
+	if roundNumber == 0 {
+		roundNumber = currentRound(project)
+	}
+
+	results, err := s.resultRepo.GetRangkings(projectID, roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	return buildConsensus(projectID, results)
+}
+
+// aijConsensusMessage is returned for AIJ projects: only the group matrix is ranked, so there are
+// no per-DM result rows to measure agreement on
+const aijConsensusMessage = "consensus is not available in AIJ mode because decision makers have no individual rankings"
+
+// buildConsensus computes the agreement metrics from stored result rows
+func buildConsensus(projectID uint, results []models.ResultRanking) (*models.ConsensusDTO, error) {
+	if len(results) == 0 {
+		return nil, errors.New("no calculation results found for this project")
+	}
+
+	final := make(calculations.RankVector)
+	dmRanks := make(map[uint]calculations.RankVector)
+	var dmIDs []uint
+	for _, r := range results {
+		if r.ProjectDMID == nil {
+			final[r.AlternativeID] = r.Rank
+			continue
+		}
+		id := *r.ProjectDMID
+		if _, ok := dmRanks[id]; !ok {
+			dmRanks[id] = make(calculations.RankVector)
+			dmIDs = append(dmIDs, id)
+		}
+		dmRanks[id][r.AlternativeID] = r.Rank
+	}
+	if len(dmIDs) < 2 {
+		return nil, errors.New("consensus requires rankings from at least two decision makers")
+	}
+	sort.Slice(dmIDs, func(i, j int) bool { return dmIDs[i] < dmIDs[j] })
+
+	consensus := &models.ConsensusDTO{
+		ProjectID:        projectID,
+		RoundNumber:      results[0].RoundNumber,
+		DMCount:          len(dmIDs),
+		AlternativeCount: len(final),
+	}
+
+	var rankings []calculations.RankVector
+	for i, a := range dmIDs {
+		rankings = append(rankings, dmRanks[a])
+		for _, b := range dmIDs[i+1:] {
+			consensus.Pairs = append(consensus.Pairs, models.DMPairAgreementDTO{
+				ProjectDMIDA: a,
+				ProjectDMIDB: b,
+				SpearmanRho:  calculations.SpearmanRho(dmRanks[a], dmRanks[b]),
+				KendallTau:   calculations.KendallTauB(dmRanks[a], dmRanks[b]),
+			})
+		}
+	}
+	consensus.KendallW = calculations.KendallW(rankings)
+
+	pairCount := len(final) * (len(final) - 1) / 2
+	for _, id := range dmIDs {
+		distance := calculations.KendallDistance(dmRanks[id], final)
+		dm := models.DMConsensusDTO{
+			ProjectDMID:        id,
+			DistanceToFinal:    distance,
+			SpearmanRhoToFinal: calculations.SpearmanRho(dmRanks[id], final),
+			KendallTauToFinal:  calculations.KendallTauB(dmRanks[id], final),
+		}
+		if pairCount > 0 {
+			dm.NormalizedDistance = float64(distance) / float64(pairCount)
+		}
+		consensus.DecisionMakers = append(consensus.DecisionMakers, dm)
+	}
+
+	log.Printf("Konsensus proyek %d: Kendall W = %.4f", projectID, consensus.KendallW)
+	return consensus, nil
+}
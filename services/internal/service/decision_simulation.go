@@ -0,0 +1,175 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"services/internal/calculations"
+	"services/internal/models"
+)
+
+// Simulate recalculates the current round with the admin's overrides applied to an in-memory copy of
+// the inputs. Stored inputs, results and runs are left untouched.
+func (s *decisionService) Simulate(projectID uint, companyID uint, role string, req models.SimulateInput) (*models.SimulationDTO, error) {
+	if role != "admin" {
+		return nil, errors.New("only admins can run simulations")
+	}
+	project, err := s.checkProjectAccess(projectID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	input, err := s.loadCalculationInput(project)
+	if err != nil {
+		return nil, err
+	}
+	if err := applySimulationOverrides(input, req); err != nil {
+		return nil, err
+	}
+	if err := validateCalculationInput(input); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[SIMULASI] Proyek %d: %d bobot kriteria, %d bobot DM, %d skor diganti", projectID,
+		len(req.CriteriaWeights), len(req.GroupWeights), len(req.Scores))
+	output, err := s.runCalculation(input)
+	if err != nil {
+		return nil, err
+	}
+
+	altNames := make(map[uint]string)
+	for _, a := range input.alternatives {
+		altNames[a.AlternativeID] = a.Name
+	}
+	simulation := &models.SimulationDTO{
+		ProjectID:         projectID,
+		RoundNumber:       currentRound(project),
+		AggregationMethod: output.aggregation.Method,
+		GroupMode:         input.project.GroupMode,
+		WeightingMode:     input.project.WeightingMode,
+		DecisionMakers:    []models.SimulatedDMRankingDTO{},
+	}
+	for _, dm := range output.dmResults {
+		ranking := models.SimulatedDMRankingDTO{
+			ProjectDMID: dm.assignment.ProjectDMID,
+			DMUserID:    dm.assignment.DMUserID,
+			Method:      dm.assignment.Method,
+			GroupWeight: dm.assignment.GroupWeight,
+		}
+		for _, r := range dm.ranks {
+			ranking.Ranks = append(ranking.Ranks, models.SimulatedRankDTO{
+				AlternativeID: r.AlternativeID,
+				Name:          altNames[r.AlternativeID],
+				Rank:          r.Rank,
+				Score:         r.FinalScore,
+				Tied:          r.Tied,
+			})
+		}
+		simulation.DecisionMakers = append(simulation.DecisionMakers, ranking)
+	}
+	for _, r := range output.aggregation.Ranks {
+		simulation.FinalRanking = append(simulation.FinalRanking, models.SimulatedRankDTO{
+			AlternativeID: r.AlternativeID,
+			Name:          altNames[r.AlternativeID],
+			Rank:          r.Rank,
+			Score:         r.Score,
+			Tied:          r.Tied,
+		})
+	}
+	return simulation, nil
+}
+
+// applySimulationOverrides replaces the stored values in the loaded input with the requested ones.
+// The slices are copied first so nothing shared with the repositories is modified.
+func applySimulationOverrides(input *calculationInput, req models.SimulateInput) error {
+	if req.AggregationMethod != "" {
+		input.project.AggregationMethod = req.AggregationMethod
+	}
+
+	if len(req.CriteriaWeights) > 0 {
+		// Bobot kriteria admin tidak dipakai pada mode DM_DIRECT atau pembobotan objektif
+		if !usesAdminWeights(input.project.WeightingMode) {
+			return errors.New("criteria weight overrides only apply to projects weighted by the admin")
+		}
+		criteria := make([]models.Criteria, len(input.criteria))
+		copy(criteria, input.criteria)
+		index := make(map[uint]int)
+		for i, c := range criteria {
+			index[c.CriteriaID] = i
+		}
+		for _, w := range req.CriteriaWeights {
+			i, ok := index[w.CriteriaID]
+			if !ok {
+				return errors.New("criteria weight override references unknown criteria")
+			}
+			criteria[i].Weight = w.Weight
+		}
+		input.criteria = criteria
+	}
+
+	if len(req.GroupWeights) > 0 {
+		assignments := make([]models.ProjectDecisionMaker, len(input.assignments))
+		copy(assignments, input.assignments)
+		index := make(map[uint]int)
+		for i, dm := range assignments {
+			index[dm.ProjectDMID] = i
+		}
+		for _, w := range req.GroupWeights {
+			i, ok := index[w.ProjectDMID]
+			if !ok {
+				return errors.New("group weight override references unknown decision maker")
+			}
+			assignments[i].GroupWeight = w.GroupWeight
+		}
+		input.assignments = assignments
+	}
+
+	if len(req.Scores) > 0 {
+		isDM := make(map[uint]bool)
+		for _, dm := range input.assignments {
+			isDM[dm.ProjectDMID] = true
+		}
+		isAlternative := make(map[uint]bool)
+		for _, a := range input.alternatives {
+			isAlternative[a.AlternativeID] = true
+		}
+		isLeaf := make(map[uint]bool)
+		for _, c := range calculations.LeafCriteria(input.criteria) {
+			isLeaf[c.CriteriaID] = true
+		}
+
+		copied := make(map[uint]bool)
+		for _, o := range req.Scores {
+			if !isDM[o.ProjectDMID] {
+				return errors.New("score override references unknown decision maker")
+			}
+			if !isAlternative[o.AlternativeID] || !isLeaf[o.CriteriaID] {
+				return errors.New("score override must reference an alternative and a leaf criteria of this project")
+			}
+			if !copied[o.ProjectDMID] {
+				scores := make([]models.DMInputScore, len(input.scores[o.ProjectDMID]))
+				copy(scores, input.scores[o.ProjectDMID])
+				input.scores[o.ProjectDMID] = scores
+				copied[o.ProjectDMID] = true
+			}
+			overrideScore(input, o)
+		}
+	}
+	return nil
+}
+
+// overrideScore replaces the DM's score for the alternative and criteria, or adds it when it was never entered
+func overrideScore(input *calculationInput, o models.SimulateScoreInput) {
+	scores := input.scores[o.ProjectDMID]
+	for i := range scores {
+		if scores[i].AlternativeID == o.AlternativeID && scores[i].CriteriaID == o.CriteriaID {
+			scores[i].ScoreValue = o.ScoreValue
+			return
+		}
+	}
+	input.scores[o.ProjectDMID] = append(scores, models.DMInputScore{
+		ProjectDMID:   o.ProjectDMID,
+		AlternativeID: o.AlternativeID,
+		CriteriaID:    o.CriteriaID,
+		RoundNumber:   currentRound(&input.project),
+		ScoreValue:    o.ScoreValue,
+	})
+}
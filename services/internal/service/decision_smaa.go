@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"math/rand/v2"
+	"runtime"
+	"services/internal/calculations"
+	"services/internal/models"
+)
+
+const defaultSMAASamples = 1000
+
+// AnalyzeSMAA runs the TOPSIS+Borda pipeline on randomly sampled criteria weights (and optionally
+// perturbed scores) and reports how often each alternative reaches each rank. Nothing is stored.
+func (s *decisionService) AnalyzeSMAA(projectID uint, companyID uint, req models.SMAAInput) (*models.SMAADTO, error) {
+	project, err := s.checkProjectAccess(projectID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	input, err := s.loadCalculationInput(project)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCalculationInput(input); err != nil {
+		return nil, err
+	}
+	if input, err = applyMissingScorePolicy(input); err != nil {
+		return nil, err
+	}
+	if len(input.excludedCriteria) > 0 {
+		return nil, errors.New("SMAA needs complete scores; impute them instead of excluding decision makers")
+	}
+
+	leaves := calculations.LeafCriteria(input.criteria)
+	bounds, err := smaaBounds(leaves, req.WeightBounds)
+	if err != nil {
+		return nil, err
+	}
+
+	method, ok := s.methods.Get(calculations.MethodTOPSIS)
+	if !ok {
+		return nil, errors.New("decision method TOPSIS is not supported")
+	}
+	aggregator, ok := s.aggregators.Get(calculations.AggregationBorda)
+	if !ok {
+		return nil, errors.New("aggregation method BORDA is not supported")
+	}
+
+	samples := req.Samples
+	if samples == 0 {
+		samples = defaultSMAASamples
+	}
+	workers := req.Workers
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+	// 53 bit agar seed tetap utuh saat dibaca sebagai number di JavaScript
+	seed := rand.Uint64() >> 11
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+
+	cfg := calculations.SMAAConfig{
+		Samples:           samples,
+		Seed:              seed,
+		Workers:           workers,
+		Criteria:          leaves,
+		Alternatives:      input.alternatives,
+		Bounds:            bounds,
+		ScorePerturbation: req.ScorePerturbation,
+		Method:            method,
+		Aggregator:        aggregator,
+		Options: calculations.RankingOptions{
+			Normalization: input.project.Normalization,
+			Ties:          tieOptions(project),
+		},
+	}
+	for _, dm := range input.assignments {
+		cfg.ScoreSets = append(cfg.ScoreSets, input.scores[dm.ProjectDMID])
+		cfg.DMIDs = append(cfg.DMIDs, dm.ProjectDMID)
+		cfg.DMWeights = append(cfg.DMWeights, dm.GroupWeight)
+	}
+
+	log.Printf("[SMAA] Proyek %d: %d sampel, %d worker, seed %d", projectID, samples, workers, seed)
+	result, err := calculations.RunSMAA(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	smaa := &models.SMAADTO{
+		ProjectID:         projectID,
+		RoundNumber:       currentRound(project),
+		Method:            method.Name(),
+		Aggregation:       aggregator.Name(),
+		Samples:           result.Samples,
+		Seed:              result.Seed,
+		ScorePerturbation: req.ScorePerturbation,
+	}
+	for _, a := range input.alternatives {
+		smaa.Alternatives = append(smaa.Alternatives, models.SMAAAlternativeDTO{
+			AlternativeID:     a.AlternativeID,
+			Name:              a.Name,
+			RankAcceptability: result.RankAcceptability[a.AlternativeID],
+			CentralWeights:    result.CentralWeights[a.AlternativeID],
+		})
+	}
+	return smaa, nil
+}
+
+// smaaBounds checks the admin's weight bounds against the leaf criteria and against each other
+func smaaBounds(leaves []models.Criteria, inputs []models.SMAAWeightBoundInput) (map[uint]calculations.WeightBound, error) {
+	bounds := make(map[uint]calculations.WeightBound)
+	isLeaf := make(map[uint]bool)
+	for _, c := range leaves {
+		isLeaf[c.CriteriaID] = true
+	}
+	for _, b := range inputs {
+		if !isLeaf[b.CriteriaID] {
+			return nil, errors.New("weight bounds must reference leaf criteria of this project")
+		}
+		if b.Min > b.Max {
+			return nil, errors.New("weight bound min must not exceed max")
+		}
+		bounds[b.CriteriaID] = calculations.WeightBound{Min: b.Min, Max: b.Max}
+	}
+
+	minSum, maxSum := 0.0, 0.0
+	for _, c := range leaves {
+		b, ok := bounds[c.CriteriaID]
+		if !ok {
+			b = calculations.WeightBound{Min: 0, Max: 1}
+		}
+		minSum += b.Min
+		maxSum += b.Max
+	}
+	if minSum > 1 || maxSum < 1 {
+		return nil, errors.New("weight bounds cannot sum to 1")
+	}
+	return bounds, nil
+}
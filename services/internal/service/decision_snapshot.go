@@ -0,0 +1,216 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"services/internal/calculations"
+	"services/internal/models"
+)
+
+// snapshotVersion is raised whenever the layout of inputSnapshot changes; migrate upgrades older snapshots.
+// 2: criteria carry their score scale and pairwise comparisons their round number.
+const snapshotVersion = 2
+
+// inputSnapshot is everything a calculation read, in the order it read it. Restoring it and running
+// the calculation again reproduces the results bit for bit.
+type inputSnapshot struct {
+	Version       int                           `json:"version"`
+	Project       models.DecisionProject        `json:"project"`
+	Criteria      []models.Criteria             `json:"criteria"`
+	Alternatives  []models.Alternative          `json:"alternatives"`
+	Assignments   []models.ProjectDecisionMaker `json:"assignments"`
+	Scores        []models.DMInputScore         `json:"scores"`
+	DirectWeights []models.DMInputDirectWeight  `json:"direct_weights"`
+	Pairwise      []models.DMInputPairwise      `json:"pairwise"`
+}
+
+// resultDigest is the part of the output covered by the result hash; scores keep full float64 precision
+type resultDigest struct {
+	DecisionMakers []dmResultDigest               `json:"decision_makers"`
+	Aggregation    []calculations.AlternativeRank `json:"aggregation"`
+}
+
+type dmResultDigest struct {
+	ProjectDMID uint                      `json:"project_dm_id"`
+	Ranks       []calculations.TOPSISRank `json:"ranks"`
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// newInputSnapshot serializes the input; the hash is taken over exactly the stored bytes
+func newInputSnapshot(input *calculationInput) (string, string, error) {
+	snapshot := inputSnapshot{
+		Version:      snapshotVersion,
+		Project:      input.project,
+		Criteria:     input.criteria,
+		Alternatives: input.alternatives,
+		Assignments:  input.assignments,
+	}
+	for _, dm := range input.assignments {
+		snapshot.Scores = append(snapshot.Scores, input.scores[dm.ProjectDMID]...)
+		snapshot.DirectWeights = append(snapshot.DirectWeights, input.directWeights[dm.ProjectDMID]...)
+		snapshot.Pairwise = append(snapshot.Pairwise, input.pairwise[dm.ProjectDMID]...)
+	}
+
+	document, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", "", err
+	}
+	return string(document), contentHash(document), nil
+}
+
+// migrate upgrades a snapshot stored by an older version to the current layout. Version 1 predates
+// criteria scales and pairwise rounds: its criteria read as unbounded NUMERIC, like the migrated rows,
+// and its comparisons belong to the round of the run.
+func (snapshot *inputSnapshot) migrate(roundNumber int) error {
+	if snapshot.Version < 1 || snapshot.Version > snapshotVersion {
+		return fmt.Errorf("unsupported input snapshot version %d", snapshot.Version)
+	}
+	if snapshot.Version == 1 {
+		for i := range snapshot.Criteria {
+			if snapshot.Criteria[i].ScaleType == "" {
+				snapshot.Criteria[i].ScaleType = scaleNumeric
+			}
+		}
+		for i := range snapshot.Pairwise {
+			snapshot.Pairwise[i].RoundNumber = roundNumber
+		}
+		snapshot.Version = 2
+	}
+	return nil
+}
+
+// restore rebuilds the calculation input from the snapshot
+func (snapshot *inputSnapshot) restore() *calculationInput {
+	input := &calculationInput{
+		project:       snapshot.Project,
+		criteria:      snapshot.Criteria,
+		alternatives:  snapshot.Alternatives,
+		assignments:   snapshot.Assignments,
+		scores:        make(map[uint][]models.DMInputScore),
+		directWeights: make(map[uint][]models.DMInputDirectWeight),
+		pairwise:      make(map[uint][]models.DMInputPairwise),
+	}
+	for _, s := range snapshot.Scores {
+		input.scores[s.ProjectDMID] = append(input.scores[s.ProjectDMID], s)
+	}
+	for _, w := range snapshot.DirectWeights {
+		input.directWeights[w.ProjectDMID] = append(input.directWeights[w.ProjectDMID], w)
+	}
+	for _, p := range snapshot.Pairwise {
+		input.pairwise[p.ProjectDMID] = append(input.pairwise[p.ProjectDMID], p)
+	}
+	return input
+}
+
+// resultHash fingerprints the rankings of a calculation before they are rounded for storage
+func resultHash(output *calculationOutput) (string, error) {
+	digest := resultDigest{Aggregation: output.aggregation.Ranks}
+	for _, dm := range output.dmResults {
+		digest.DecisionMakers = append(digest.DecisionMakers, dmResultDigest{
+			ProjectDMID: dm.assignment.ProjectDMID,
+			Ranks:       dm.ranks,
+		})
+	}
+	document, err := json.Marshal(digest)
+	if err != nil {
+		return "", err
+	}
+	return contentHash(document), nil
+}
+
+// loadRunSnapshot reads and parses the input snapshot stored with a run
+func (s *decisionService) loadRunSnapshot(projectID uint, companyID uint, runID uint) (*models.CalculationRun, *inputSnapshot, error) {
+	if _, err := s.checkProjectAccess(projectID, companyID); err != nil {
+		return nil, nil, err
+	}
+	run, err := s.runRepo.GetRun(projectID, runID)
+	if err != nil {
+		return nil, nil, errors.New("calculation run not found")
+	}
+	if run.InputSnapshot == "" {
+		return nil, nil, errors.New("calculation run has no input snapshot")
+	}
+
+	var snapshot inputSnapshot
+	if err := json.Unmarshal([]byte(run.InputSnapshot), &snapshot); err != nil {
+		return nil, nil, err
+	}
+	return run, &snapshot, nil
+}
+
+// GetRunSnapshot returns the inputs a run was calculated from and whether they still match the stored hash
+func (s *decisionService) GetRunSnapshot(projectID uint, companyID uint, runID uint) (*models.RunSnapshotDTO, error) {
+	run, _, err := s.loadRunSnapshot(projectID, companyID, runID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.RunSnapshotDTO{
+		RunID:          run.RunID,
+		ProjectID:      run.ProjectID,
+		RoundNumber:    run.RoundNumber,
+		InputHash:      run.InputHash,
+		InputHashValid: contentHash([]byte(run.InputSnapshot)) == run.InputHash,
+		Snapshot:       json.RawMessage(run.InputSnapshot),
+	}, nil
+}
+
+// ReplayRun recalculates a run from its snapshot, ignoring any later edits, and compares the result hashes
+func (s *decisionService) ReplayRun(projectID uint, companyID uint, runID uint) (*models.RunReplayDTO, error) {
+	run, snapshot, err := s.loadRunSnapshot(projectID, companyID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := snapshot.migrate(run.RoundNumber); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Mengulang run perhitungan %d dari snapshot", run.RunID)
+	output, err := s.runCalculation(snapshot.restore())
+	if err != nil {
+		return nil, err
+	}
+	replayHash, err := resultHash(output)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.resultRepo.GetRankingsByRun(run.RunID)
+	if err != nil {
+		return nil, err
+	}
+	storedRanks := make(map[uint]int)
+	for _, r := range stored {
+		if r.ProjectDMID == nil {
+			storedRanks[r.AlternativeID] = r.Rank
+		}
+	}
+
+	inputHashValid := contentHash([]byte(run.InputSnapshot)) == run.InputHash
+	replay := &models.RunReplayDTO{
+		RunID:            run.RunID,
+		ProjectID:        run.ProjectID,
+		InputHash:        run.InputHash,
+		InputHashValid:   inputHashValid,
+		ResultHash:       run.ResultHash,
+		ReplayResultHash: replayHash,
+		Reproducible:     inputHashValid && replayHash == run.ResultHash,
+	}
+	for _, r := range output.aggregation.Ranks {
+		replay.FinalRanking = append(replay.FinalRanking, models.ReplayRankDTO{
+			AlternativeID: r.AlternativeID,
+			Rank:          r.Rank,
+			Score:         r.Score,
+			StoredRank:    storedRanks[r.AlternativeID],
+		})
+	}
+	return replay, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"services/internal/calculations"
+	"services/internal/models"
+)
+
+// calculationTrace is the JSON document stored per calculated round. Matrices are keyed by
+// alternative ID and then criteria ID, so they line up with the rows and columns of the spreadsheet.
+type calculationTrace struct {
+	RoundNumber       int                             `json:"round_number"`
+	AggregationMethod string                          `json:"aggregation_method"`
+	GroupMode         string                          `json:"group_mode"`
+	WeightingMode     string                          `json:"weighting_mode"`
+	DecisionMakers    []*dmTrace                      `json:"decision_makers,omitempty"`
+	Group             *dmTrace                        `json:"group,omitempty"`
+	Aggregation       *calculations.AggregationResult `json:"aggregation"`
+}
+
+// dmTrace explains one DM's ranking: the weights used, the method's intermediate values and the result
+type dmTrace struct {
+	ProjectDMID uint                           `json:"project_dm_id,omitempty"`
+	Method      string                         `json:"method"`
+	Weights     map[uint]float64               `json:"weights"`
+	TOPSIS      *calculations.TOPSISTrace      `json:"topsis,omitempty"`
+	WP          *calculations.WPResult         `json:"wp,omitempty"`
+	Ranks       []calculations.AlternativeRank `json:"ranks"`
+}
+
+func newDMTrace(projectDMID uint, method string, weights map[uint]float64, topsis *calculations.TOPSISTrace, wp *calculations.WPResult, ranks []calculations.TOPSISRank) *dmTrace {
+	trace := &dmTrace{
+		ProjectDMID: projectDMID,
+		Method:      method,
+		Weights:     weights,
+		TOPSIS:      topsis,
+		WP:          wp,
+	}
+	for _, r := range ranks {
+		trace.Ranks = append(trace.Ranks, calculations.AlternativeRank{
+			AlternativeID: r.AlternativeID,
+			Rank:          r.Rank,
+			Score:         r.FinalScore,
+			Tied:          r.Tied,
+		})
+	}
+	return trace
+}
+
+// buildTrace turns the traces collected during the calculation into the row stored with the run
+func buildTrace(input *calculationInput, output *calculationOutput) (*models.CalculationTrace, error) {
+	project := &input.project
+	trace := calculationTrace{
+		RoundNumber:       currentRound(project),
+		AggregationMethod: project.AggregationMethod,
+		GroupMode:         project.GroupMode,
+		WeightingMode:     project.WeightingMode,
+		Aggregation:       output.aggregation,
+	}
+	if calculations.IsAIJ(project.GroupMode) {
+		// Pada mode AIJ hanya ada satu DM kelompok semu dengan ID 0
+		trace.Group = input.traces[0]
+	} else {
+		for _, dm := range input.assignments {
+			if t, ok := input.traces[dm.ProjectDMID]; ok {
+				trace.DecisionMakers = append(trace.DecisionMakers, t)
+			}
+		}
+	}
+
+	document, err := json.Marshal(trace)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CalculationTrace{
+		ProjectID:   project.ProjectID,
+		RoundNumber: trace.RoundNumber,
+		Trace:       string(document),
+	}, nil
+}
+
+// GetTrace returns the trace of a run; without a run it is the latest run of the round (0 = current)
+func (s *decisionService) GetTrace(projectID uint, companyID uint, roundNumber int, runID uint) (*models.CalculationTraceDTO, error) {
+	project, err := s.checkProjectAccess(projectID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.resolveRun(project, roundNumber, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, errors.New("no calculation trace found for this project")
+	}
+
+	trace, err := s.traceRepo.GetTraceByRun(run.RunID)
+	if err != nil {
+		return nil, errors.New("no calculation trace found for this project")
+	}
+	return &models.CalculationTraceDTO{
+		ProjectID:   trace.ProjectID,
+		RoundNumber: trace.RoundNumber,
+		RunID:       run.RunID,
+		CreatedAt:   trace.CreatedAt,
+		Trace:       json.RawMessage(trace.Trace),
+	}, nil
+}
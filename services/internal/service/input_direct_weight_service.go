@@ -2,10 +2,15 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"services/internal/calculations"
 	"services/internal/models"
 	"services/internal/repository"
 )
 
+// weightSumTolerance is the rounding slack allowed when weights must sum to 1
+const weightSumTolerance = 0.01
+
 type InputDirectWeightService interface {
 	SubmitDirectWeights(input models.SubmitDirectWeightsInput, projectID uint, dmUserID uint) error
 	GetDirectWeights(projectID uint, dmUserID uint) ([]models.DMInputDirectWeight, error)
@@ -14,15 +19,21 @@ type InputDirectWeightService interface {
 type inputDirectWeightService struct {
 	directWeightRepo repository.InputDirectWeightRepository
 	projectDMRepo    repository.ProjectDMRepository
+	criteriaRepo     repository.CriteriaRepository
+	projectRepo      repository.ProjectRepository
 }
 
 func NewInputDirectWeightService(
 	directWeightRepo repository.InputDirectWeightRepository,
 	projectDMRepo repository.ProjectDMRepository,
+	criteriaRepo repository.CriteriaRepository,
+	projectRepo repository.ProjectRepository,
 ) InputDirectWeightService {
 	return &inputDirectWeightService{
 		directWeightRepo: directWeightRepo,
 		projectDMRepo:    projectDMRepo,
+		criteriaRepo:     criteriaRepo,
+		projectRepo:      projectRepo,
 	}
 }
 
@@ -34,16 +45,31 @@ func (s *inputDirectWeightService) SubmitDirectWeights(input models.SubmitDirect
 		return errors.New("user is not an assigned decision maker for this project")
 	}
 
+	round, err := s.projectRepo.GetCurrentRound(projectID)
+	if err != nil {
+		return err
+	}
+
 	var weights []models.DMInputDirectWeight
 	for _, item := range input.Weights {
 		model := models.DMInputDirectWeight{
 			ProjectDMID: assignment.ProjectDMID,
 			CriteriaID:  item.CriteriaID,
+			RoundNumber: round,
 			WeightValue: item.WeightValue,
 		}
 		weights = append(weights, model)
 	}
-	if err := s.directWeightRepo.BatchUsertWeights(assignment.ProjectDMID, weights); err != nil {
+
+	allCriteria, err := s.criteriaRepo.GetCriteriaByProjectID(projectID)
+	if err != nil {
+		return err
+	}
+	if err := validateDirectWeights(allCriteria, weights); err != nil {
+		return err
+	}
+
+	if err := s.directWeightRepo.BatchUsertWeights(assignment.ProjectDMID, round, weights); err != nil {
 		return err
 	}
 
@@ -54,5 +80,41 @@ func (s *inputDirectWeightService) GetDirectWeights(projectID uint, dmUserID uin
 	if err != nil {
 		return nil, errors.New("user is not an assigned decision maker for this project")
 	}
-	return s.directWeightRepo.GetDIrectWeightls(assignment.ProjectDMID)
+	round, err := s.projectRepo.GetCurrentRound(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.directWeightRepo.GetDIrectWeightls(assignment.ProjectDMID, round)
+}
+
+// validateDirectWeights checks that a DM's weights cover every criterion exactly once
+// and that every group of sibling criteria sums to 1
+func validateDirectWeights(criteria []models.Criteria, weights []models.DMInputDirectWeight) error {
+	criteriaMap := make(map[uint]models.Criteria)
+	for _, c := range criteria {
+		criteriaMap[c.CriteriaID] = c
+	}
+
+	seen := make(map[uint]bool)
+	local := make(map[uint]float64)
+	for _, w := range weights {
+		if _, ok := criteriaMap[w.CriteriaID]; !ok {
+			return fmt.Errorf("invalid direct weights: criteria %d does not belong to this project", w.CriteriaID)
+		}
+		if seen[w.CriteriaID] {
+			return fmt.Errorf("invalid direct weights: criteria %d is weighted more than once", w.CriteriaID)
+		}
+		seen[w.CriteriaID] = true
+		local[w.CriteriaID] = w.WeightValue
+	}
+
+	for _, c := range criteria {
+		if !seen[c.CriteriaID] {
+			return fmt.Errorf("invalid direct weights: criteria %s has no weight", c.Name)
+		}
+	}
+	if err := calculations.ValidateSiblingWeights(criteria, local, weightSumTolerance); err != nil {
+		return fmt.Errorf("invalid direct weights: %v", err)
+	}
+	return nil
 }
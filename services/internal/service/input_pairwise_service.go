@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"services/internal/calculations"
+	"services/internal/models"
+	"services/internal/repository"
+	"sort"
+)
+
+const inconsistentPairwiseMessage = "pairwise comparison matrix is inconsistent (CR > 0.1), please revise your judgments"
+
+// pairwiseValueTolerance absorbs the decimal(10,4) rounding, so 1/9 read back as 0.1111 is accepted again
+const pairwiseValueTolerance = 1e-4
+
+type InputPairwiseService interface {
+	SubmitPairwise(input models.SumbitPairwiseInput, projectID uint, dmUserID uint) (*models.PairwiseResultDTO, error)
+	GetPairwise(projectID uint, dmUserID uint, method string) (*models.PairwiseResultDTO, error)
+}
+
+type inputPairwiseService struct {
+	pairwiseRepo  repository.InputPairwiseRepository
+	projectDMRepo repository.ProjectDMRepository
+	criteriaRepo  repository.CriteriaRepository
+	projectRepo   repository.ProjectRepository
+	ahpCalc       calculations.AHPCalculator
+}
+
+func NewInputPairwiseService(
+	pairwiseRepo repository.InputPairwiseRepository,
+	projectDMRepo repository.ProjectDMRepository,
+	criteriaRepo repository.CriteriaRepository,
+	projectRepo repository.ProjectRepository,
+	ahpCalc calculations.AHPCalculator,
+) InputPairwiseService {
+	return &inputPairwiseService{
+		pairwiseRepo:  pairwiseRepo,
+		projectDMRepo: projectDMRepo,
+		criteriaRepo:  criteriaRepo,
+		projectRepo:   projectRepo,
+		ahpCalc:       ahpCalc,
+	}
+}
+
+func (s *inputPairwiseService) SubmitPairwise(input models.SumbitPairwiseInput, projectID uint, dmUserID uint) (*models.PairwiseResultDTO, error) {
+	assignment, err := s.projectDMRepo.GetAssignmentByProjectAndUser(projectID, dmUserID)
+	if err != nil {
+		return nil, errors.New("user is not an assigned decision maker for this project")
+	}
+
+	allCriteria, err := s.criteriaRepo.GetCriteriaByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	criteriaMap := make(map[uint]models.Criteria)
+	for _, c := range allCriteria {
+		criteriaMap[c.CriteriaID] = c
+	}
+
+	round, err := s.projectRepo.GetCurrentRound(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var comparisons []models.DMInputPairwise
+	for _, item := range input.Comparisons {
+		c1, ok1 := criteriaMap[item.Cirteria1ID]
+		c2, ok2 := criteriaMap[item.Cirteria2ID]
+		if !ok1 || !ok2 {
+			return nil, errors.New("criteria does not belong to this project")
+		}
+		if !sameParent(c1.ParentCriteriaID, item.PrentCriteriaID) || !sameParent(c2.ParentCriteriaID, item.PrentCriteriaID) {
+			return nil, errors.New("compared criteria must share the given parent criteria")
+		}
+		if item.Value < 1.0/9-pairwiseValueTolerance || item.Value > 9+pairwiseValueTolerance {
+			return nil, errors.New("pairwise comparison value must be between 1/9 and 9")
+		}
+
+		comparisons = append(comparisons, models.DMInputPairwise{
+			ProjectDMID:      assignment.ProjectDMID,
+			Criteria1ID:      item.Cirteria1ID,
+			Criteria2ID:      item.Cirteria2ID,
+			ParentCriteriaID: item.PrentCriteriaID,
+			RoundNumber:      round,
+			Value:            item.Value,
+		})
+	}
+
+	result, err := buildPairwiseResult(s.ahpCalc, allCriteria, comparisons, input.Method)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Consistent {
+		return result, errors.New(inconsistentPairwiseMessage)
+	}
+
+	if err := s.pairwiseRepo.BatchUpsertPairwise(assignment.ProjectDMID, round, comparisons); err != nil {
+		return nil, err
+	}
+	result.Comparisons = comparisons
+	return result, nil
+}
+
+func (s *inputPairwiseService) GetPairwise(projectID uint, dmUserID uint, method string) (*models.PairwiseResultDTO, error) {
+	assignment, err := s.projectDMRepo.GetAssignmentByProjectAndUser(projectID, dmUserID)
+	if err != nil {
+		return nil, errors.New("user is not an assigned decision maker for this project")
+	}
+
+	allCriteria, err := s.criteriaRepo.GetCriteriaByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	round, err := s.projectRepo.GetCurrentRound(projectID)
+	if err != nil {
+		return nil, err
+	}
+	comparisons, err := s.pairwiseRepo.GetPairwise(assignment.ProjectDMID, round)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := buildPairwiseResult(s.ahpCalc, allCriteria, comparisons, method)
+	if err != nil {
+		return nil, err
+	}
+	result.Comparisons = comparisons
+	return result, nil
+}
+
+// buildPairwiseResult groups comparisons per parent criteria and runs AHP on every group of siblings.
+func buildPairwiseResult(
+	ahpCalc calculations.AHPCalculator,
+	allCriteria []models.Criteria,
+	comparisons []models.DMInputPairwise,
+	method string,
+) (*models.PairwiseResultDTO, error) {
+	if method == "" {
+		method = calculations.AHPMethodEigenvector
+	}
+
+	siblings := make(map[uint][]uint)
+	for _, c := range allCriteria {
+		siblings[parentKey(c.ParentCriteriaID)] = append(siblings[parentKey(c.ParentCriteriaID)], c.CriteriaID)
+	}
+
+	grouped := make(map[uint][]models.DMInputPairwise)
+	parents := make(map[uint]*uint)
+	for _, cmp := range comparisons {
+		key := parentKey(cmp.ParentCriteriaID)
+		grouped[key] = append(grouped[key], cmp)
+		parents[key] = cmp.ParentCriteriaID
+	}
+
+	keys := make([]uint, 0, len(grouped))
+	for key := range grouped {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	result := &models.PairwiseResultDTO{
+		Method:      method,
+		Consistent:  true,
+		Comparisons: []models.DMInputPairwise{},
+		Groups:      []models.PairwiseGroupResultDTO{},
+	}
+	for _, key := range keys {
+		ahp, err := ahpCalc.CalculatePriorities(siblings[key], grouped[key], method)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pairwise comparisons: %v", err)
+		}
+
+		result.Groups = append(result.Groups, models.PairwiseGroupResultDTO{
+			ParentCriteriaID: parents[key],
+			Priorities:       ahp.Priorities,
+			LambdaMax:        ahp.LambdaMax,
+			CI:               ahp.CI,
+			RI:               ahp.RI,
+			CR:               ahp.CR,
+			Consistent:       ahp.Consistent,
+		})
+		if !ahp.Consistent {
+			result.Consistent = false
+		}
+	}
+
+	return result, nil
+}
+
+// parentKey maps a nullable parent ID to a map key; 0 is used for root criteria.
+func parentKey(parentID *uint) uint {
+	if parentID == nil {
+		return 0
+	}
+	return *parentID
+}
+
+func sameParent(a *uint, b *uint) bool {
+	return parentKey(a) == parentKey(b)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"services/internal/calculations"
 	"services/internal/models"
 	"services/internal/repository"
 )
@@ -15,18 +16,55 @@ type InputScoreService interface {
 type inputScoreService struct {
 	scoreRepo     repository.InputScoreRepository
 	projectDMRepo repository.ProjectDMRepository
+	criteriaRepo  repository.CriteriaRepository
+	projectRepo   repository.ProjectRepository
 }
 
 func NewInputScoreService(
 	scoreRepo repository.InputScoreRepository,
 	projectDMRepo repository.ProjectDMRepository,
+	criteriaRepo repository.CriteriaRepository,
+	projectRepo repository.ProjectRepository,
 ) InputScoreService {
 	return &inputScoreService{
 		scoreRepo:     scoreRepo,
 		projectDMRepo: projectDMRepo,
+		criteriaRepo:  criteriaRepo,
+		projectRepo:   projectRepo,
 	}
 }
 
+// validateScoreTargets makes sure every score targets a leaf criterion of the project and fits its scale
+func (s *inputScoreService) validateScoreTargets(projectID uint, items []models.ScoreInputItem) error {
+	allCriteria, err := s.criteriaRepo.GetCriteriaByProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	projectCriteria := make(map[uint]*models.Criteria)
+	for i, c := range allCriteria {
+		projectCriteria[c.CriteriaID] = &allCriteria[i]
+	}
+	leaves := make(map[uint]bool)
+	for _, c := range calculations.LeafCriteria(allCriteria) {
+		leaves[c.CriteriaID] = true
+	}
+
+	for _, item := range items {
+		criteria, ok := projectCriteria[item.CriteriaID]
+		if !ok {
+			return errors.New("criteria does not belong to this project")
+		}
+		if !leaves[item.CriteriaID] {
+			return errors.New("scores can only be submitted for leaf criteria")
+		}
+		if err := validateScore(criteria, item.ScoreValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *inputScoreService) SubmitScores(input models.SubmitScoreInput, projectID uint, dmUserID uint) error {
 
 	assignment, err := s.projectDMRepo.GetAssignmentByProjectAndUser(projectID, dmUserID)
@@ -34,18 +72,28 @@ func (s *inputScoreService) SubmitScores(input models.SubmitScoreInput, projectI
 		return errors.New("user is not an assigned decision maker for this project")
 	}
 
+	if err := s.validateScoreTargets(projectID, input.Scores); err != nil {
+		return err
+	}
+
+	round, err := s.projectRepo.GetCurrentRound(projectID)
+	if err != nil {
+		return err
+	}
+
 	var scores []models.DMInputScore
 	for _, item := range input.Scores {
 		model := models.DMInputScore{
 			ProjectDMID:   assignment.ProjectDMID,
 			AlternativeID: item.AlternativeID,
 			CriteriaID:    item.CriteriaID,
+			RoundNumber:   round,
 			ScoreValue:    item.ScoreValue,
 		}
 		scores = append(scores, model)
 	}
 
-	return s.scoreRepo.BatchUpsertInputScores(assignment.ProjectDMID, scores)
+	return s.scoreRepo.BatchUpsertInputScores(assignment.ProjectDMID, round, scores)
 }
 
 func (s *inputScoreService) SubmitScore(input models.ScoreInputItem, projectID uint, dmUserID uint) error {
@@ -54,10 +102,20 @@ func (s *inputScoreService) SubmitScore(input models.ScoreInputItem, projectID u
 		return errors.New("user is not an assigned decision maker for this project")
 	}
 
+	if err := s.validateScoreTargets(projectID, []models.ScoreInputItem{input}); err != nil {
+		return err
+	}
+
+	round, err := s.projectRepo.GetCurrentRound(projectID)
+	if err != nil {
+		return err
+	}
+
 	score := models.DMInputScore{
 		ProjectDMID:   assignment.ProjectDMID,
 		AlternativeID: input.AlternativeID,
 		CriteriaID:    input.CriteriaID,
+		RoundNumber:   round,
 		ScoreValue:    input.ScoreValue,
 	}
 
@@ -69,5 +127,9 @@ func (s *inputScoreService) GetScores(projectID uint, dmUserID uint) ([]models.D
 	if err != nil {
 		return nil, errors.New("user is not an assigned decision maker for this project")
 	}
-	return s.scoreRepo.GetScores(assignment.ProjectDMID)
+	round, err := s.projectRepo.GetCurrentRound(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.scoreRepo.GetScores(assignment.ProjectDMID, round)
 }
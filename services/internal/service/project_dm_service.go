@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"services/internal/calculations"
 	"services/internal/models"
 	"services/internal/repository"
 )
@@ -27,24 +28,37 @@ type projectDMService struct {
 	projectDMRepo repository.ProjectDMRepository
 	projectRepo   repository.ProjectRepository
 	userRepo      repository.UserRepository
+	methods       calculations.MethodRegistry
 }
 
 func NewProjectDMService(
 	projectDMRepo repository.ProjectDMRepository,
 	projectRepo repository.ProjectRepository,
 	userRepo repository.UserRepository,
+	methods calculations.MethodRegistry,
 ) ProjectDMService {
 	return &projectDMService{
 		projectDMRepo: projectDMRepo,
 		projectRepo:   projectRepo,
 		userRepo:      userRepo,
+		methods:       methods,
 	}
 }
 
+func (s *projectDMService) validateMethod(method string) error {
+	if _, ok := s.methods.Get(method); !ok {
+		return errors.New("unsupported decision method")
+	}
+	return nil
+}
+
 func (s *projectDMService) AssignDM(input models.AssignDMInput, projectID uint, adminCompanyID uint, adminRole string) (*models.ProjectDMDTO, error) {
 	if adminRole != "admin" {
 		return nil, errors.New("only admins can assign decision makers")
 	}
+	if err := s.validateMethod(input.Method); err != nil {
+		return nil, err
+	}
 
 	project, err := s.projectRepo.GetProjectByID(projectID, adminCompanyID)
 	if err != nil {
@@ -132,6 +146,9 @@ func (s *projectDMService) UpdateAssignment(projectDMID uint, input models.Updat
 	if adminRole != "admin" {
 		return nil, errors.New("only admins can update decision maker assignments")
 	}
+	if err := s.validateMethod(input.Method); err != nil {
+		return nil, err
+	}
 
 	// Get the assignment
 	assignment, err := s.projectDMRepo.GetAssignmentByID(projectDMID)
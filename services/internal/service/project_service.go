@@ -1,6 +1,8 @@
 package service
 
 import (
+	"errors"
+	"services/internal/calculations"
 	"services/internal/models"
 	"services/internal/repository"
 	"time"
@@ -8,17 +10,29 @@ import (
 
 func toProjectDTO(project *models.DecisionProject) models.ProjectDTO {
 	return models.ProjectDTO{
-		ProjectID:         project.ProjectID,
-		CompanyID:         project.CompanyID,
-		CreatedByAdminID:  project.CreatedByAdminID,
-		ProjectName:       project.ProjectName,
-		Description:       project.Description,
-		Status:            project.Status,
-		AggregationMethod: project.AggregationMethod,
-		CrateAt:           project.CreatedAt,
+		ProjectID:          project.ProjectID,
+		CompanyID:          project.CompanyID,
+		CreatedByAdminID:   project.CreatedByAdminID,
+		ProjectName:        project.ProjectName,
+		Description:        project.Description,
+		Status:             project.Status,
+		AggregationMethod:  project.AggregationMethod,
+		WeightingMode:      project.WeightingMode,
+		WeightBlend:        project.WeightBlend,
+		Normalization:      project.Normalization,
+		TiePolicy:          project.TiePolicy,
+		TieBreakRule:       project.TieBreakRule,
+		GroupMode:          project.GroupMode,
+		CurrentRound:       project.CurrentRound,
+		ConsensusThreshold: project.ConsensusThreshold,
+		MissingScorePolicy: project.MissingScorePolicy,
+		CrateAt:            project.CreatedAt,
 	}
 }
 
+// defaultConsensusThreshold is the Kendall's W usually read as strong agreement
+const defaultConsensusThreshold = 0.7
+
 type ProjectService interface {
 	CreateProject(input models.CreateProjectInput, adminID uint, companyID uint) (*models.ProjectDTO, error)
 	GetProjectByID(projectID uint, companyID uint) (*models.ProjectDTO, error)
@@ -30,24 +44,69 @@ type ProjectService interface {
 
 type projectService struct {
 	projectRepo repository.ProjectRepository
+	aggregators calculations.AggregatorRegistry
 }
 
-func NewProjectService(projectRepo repository.ProjectRepository) ProjectService {
+func NewProjectService(projectRepo repository.ProjectRepository, aggregators calculations.AggregatorRegistry) ProjectService {
 	return &projectService{
 		projectRepo: projectRepo,
+		aggregators: aggregators,
+	}
+}
+
+func (s *projectService) validateAggregationMethod(method string) error {
+	if _, ok := s.aggregators.Get(method); !ok {
+		return errors.New("unknown aggregation method")
 	}
+	return nil
 }
 
 func (s *projectService) CreateProject(input models.CreateProjectInput, adminID uint, companyID uint) (*models.ProjectDTO, error) {
+	if err := s.validateAggregationMethod(input.AggregationMethod); err != nil {
+		return nil, err
+	}
 
 	newProject := models.DecisionProject{
-		ProjectName:       input.ProjectName,
-		Description:       input.Description,
-		AggregationMethod: input.AggregationMethod,
-		CompanyID:         companyID,
-		CreatedByAdminID:  adminID,
-		Status:            "setup",
-		CreatedAt:         time.Now(),
+		ProjectName:        input.ProjectName,
+		Description:        input.Description,
+		AggregationMethod:  input.AggregationMethod,
+		WeightingMode:      weightingAdmin,
+		WeightBlend:        0.5,
+		Normalization:      calculations.NormalizationVector,
+		TiePolicy:          calculations.TiePolicyShared,
+		TieBreakRule:       calculations.TieBreakAlternativeID,
+		GroupMode:          calculations.GroupModeAIP,
+		CurrentRound:       1,
+		ConsensusThreshold: defaultConsensusThreshold,
+		MissingScorePolicy: calculations.MissingScoreBlock,
+		CompanyID:          companyID,
+		CreatedByAdminID:   adminID,
+		Status:             "setup",
+		CreatedAt:          time.Now(),
+	}
+	if input.WeightingMode != "" {
+		newProject.WeightingMode = input.WeightingMode
+	}
+	if input.WeightBlend != nil {
+		newProject.WeightBlend = *input.WeightBlend
+	}
+	if input.Normalization != "" {
+		newProject.Normalization = input.Normalization
+	}
+	if input.TiePolicy != "" {
+		newProject.TiePolicy = input.TiePolicy
+	}
+	if input.TieBreakRule != "" {
+		newProject.TieBreakRule = input.TieBreakRule
+	}
+	if input.GroupMode != "" {
+		newProject.GroupMode = input.GroupMode
+	}
+	if input.ConsensusThreshold != nil {
+		newProject.ConsensusThreshold = *input.ConsensusThreshold
+	}
+	if input.MissingScorePolicy != "" {
+		newProject.MissingScorePolicy = input.MissingScorePolicy
 	}
 
 	err := s.projectRepo.CreateProject(&newProject)
@@ -104,8 +163,35 @@ func (s *projectService) UpdateProject(projectID uint, input models.UpdateProjec
 		project.Status = input.Status
 	}
 	if input.AggregationMethod != "" {
+		if err := s.validateAggregationMethod(input.AggregationMethod); err != nil {
+			return nil, err
+		}
 		project.AggregationMethod = input.AggregationMethod
 	}
+	if input.WeightingMode != "" {
+		project.WeightingMode = input.WeightingMode
+	}
+	if input.WeightBlend != nil {
+		project.WeightBlend = *input.WeightBlend
+	}
+	if input.Normalization != "" {
+		project.Normalization = input.Normalization
+	}
+	if input.TiePolicy != "" {
+		project.TiePolicy = input.TiePolicy
+	}
+	if input.TieBreakRule != "" {
+		project.TieBreakRule = input.TieBreakRule
+	}
+	if input.GroupMode != "" {
+		project.GroupMode = input.GroupMode
+	}
+	if input.ConsensusThreshold != nil {
+		project.ConsensusThreshold = *input.ConsensusThreshold
+	}
+	if input.MissingScorePolicy != "" {
+		project.MissingScorePolicy = input.MissingScorePolicy
+	}
 
 	err = s.projectRepo.UpdateProject(project)
 	if err != nil {
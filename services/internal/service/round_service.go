@@ -0,0 +1,237 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"services/internal/calculations"
+	"services/internal/models"
+	"services/internal/repository"
+	"sort"
+)
+
+type RoundService interface {
+	GetRounds(projectID uint, companyID uint) ([]models.ProjectRound, error)
+	OpenNextRound(projectID uint, companyID uint, role string) (*models.ProjectRound, error)
+	GetRoundFeedback(projectID uint, dmUserID uint) (*models.RoundFeedbackDTO, error)
+}
+
+type roundService struct {
+	roundRepo     repository.ProjectRoundRepository
+	projectRepo   repository.ProjectRepository
+	projectDMRepo repository.ProjectDMRepository
+	scoreRepo     repository.InputScoreRepository
+	directWtRepo  repository.InputDirectWeightRepository
+	resultRepo    repository.ResultRankingRepository
+}
+
+func NewRoundService(
+	roundRepo repository.ProjectRoundRepository,
+	projectRepo repository.ProjectRepository,
+	projectDMRepo repository.ProjectDMRepository,
+	scoreRepo repository.InputScoreRepository,
+	directWtRepo repository.InputDirectWeightRepository,
+	resultRepo repository.ResultRankingRepository,
+) RoundService {
+	return &roundService{
+		roundRepo:     roundRepo,
+		projectRepo:   projectRepo,
+		projectDMRepo: projectDMRepo,
+		scoreRepo:     scoreRepo,
+		directWtRepo:  directWtRepo,
+		resultRepo:    resultRepo,
+	}
+}
+
+// currentRound is the project's running round; projects created before rounds existed are in round 1
+func currentRound(project *models.DecisionProject) int {
+	if project.CurrentRound == 0 {
+		return 1
+	}
+	return project.CurrentRound
+}
+
+func (s *roundService) GetRounds(projectID uint, companyID uint) ([]models.ProjectRound, error) {
+	project, err := s.projectRepo.GetProjectByID(projectID, companyID)
+	if err != nil {
+		return nil, errors.New("project not found or user does not have access")
+	}
+
+	rounds, err := s.roundRepo.GetRounds(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Putaran berjalan belum punya baris sampai putaran berikutnya dibuka
+	current := currentRound(project)
+	if len(rounds) == 0 || rounds[len(rounds)-1].RoundNumber < current {
+		rounds = append(rounds, models.ProjectRound{
+			ProjectID:   projectID,
+			RoundNumber: current,
+			Status:      "open",
+			OpenedAt:    project.CreatedAt,
+		})
+	}
+	return rounds, nil
+}
+
+// OpenNextRound closes the current round and opens the next one when the group's consensus
+// (Kendall's W of the current round's results) is below the project's threshold
+func (s *roundService) OpenNextRound(projectID uint, companyID uint, role string) (*models.ProjectRound, error) {
+	if role != "admin" {
+		return nil, errors.New("only admins can open a new round")
+	}
+	project, err := s.projectRepo.GetProjectByID(projectID, companyID)
+	if err != nil {
+		return nil, errors.New("project not found or user does not have access")
+	}
+	if calculations.IsAIJ(project.GroupMode) {
+		return nil, errors.New(aijConsensusMessage)
+	}
+
+	results, err := s.resultRepo.GetRangkings(projectID, currentRound(project))
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, errors.New("calculate the current round before opening a new one")
+	}
+	consensus, err := buildConsensus(projectID, results)
+	if err != nil {
+		return nil, err
+	}
+	if consensus.KendallW >= project.ConsensusThreshold {
+		return nil, errors.New("consensus threshold already reached")
+	}
+
+	log.Printf("Proyek %d: Kendall W %.4f < %.4f, membuka putaran %d",
+		projectID, consensus.KendallW, project.ConsensusThreshold, currentRound(project)+1)
+	return s.roundRepo.OpenNextRound(projectID, currentRound(project), &consensus.KendallW)
+}
+
+// GetRoundFeedback shows a DM the previous round's group statistics next to their own inputs
+func (s *roundService) GetRoundFeedback(projectID uint, dmUserID uint) (*models.RoundFeedbackDTO, error) {
+	assignment, err := s.projectDMRepo.GetAssignmentByProjectAndUser(projectID, dmUserID)
+	if err != nil {
+		return nil, errors.New("user is not an assigned decision maker for this project")
+	}
+	current, err := s.projectRepo.GetCurrentRound(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if current < 2 {
+		return nil, errors.New("feedback is available from the second round onwards")
+	}
+	previous := current - 1
+
+	feedback := &models.RoundFeedbackDTO{
+		ProjectID:     projectID,
+		CurrentRound:  current,
+		FeedbackRound: previous,
+	}
+
+	rounds, err := s.roundRepo.GetRounds(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rounds {
+		if r.RoundNumber == previous {
+			feedback.KendallW = r.KendallW
+		}
+	}
+
+	// Ranking final putaran sebelumnya beserta ranking DM sendiri
+	results, err := s.resultRepo.GetRangkings(projectID, previous)
+	if err != nil {
+		return nil, err
+	}
+	yourRanks := make(map[uint]int)
+	for _, r := range results {
+		if r.ProjectDMID != nil && *r.ProjectDMID == assignment.ProjectDMID {
+			yourRanks[r.AlternativeID] = r.Rank
+		}
+	}
+	for _, r := range results {
+		if r.ProjectDMID != nil {
+			continue
+		}
+		rank := models.RoundFeedbackRankDTO{AlternativeID: r.AlternativeID, Rank: r.Rank, FinalScore: r.FinalScore}
+		if yours, ok := yourRanks[r.AlternativeID]; ok {
+			rank.YourRank = &yours
+		}
+		feedback.FinalRanking = append(feedback.FinalRanking, rank)
+	}
+
+	// Statistik skor dan bobot seluruh DM tanpa identitas
+	assignments, err := s.projectDMRepo.GetAssignmentsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	type cell struct{ alternativeID, criteriaID uint }
+	cellValues := make(map[cell][]float64)
+	yourScores := make(map[cell]float64)
+	weightValues := make(map[uint][]float64)
+	yourWeights := make(map[uint]float64)
+	for _, dm := range assignments {
+		scores, err := s.scoreRepo.GetScores(dm.ProjectDMID, previous)
+		if err != nil {
+			return nil, err
+		}
+		for _, sc := range scores {
+			key := cell{sc.AlternativeID, sc.CriteriaID}
+			cellValues[key] = append(cellValues[key], sc.ScoreValue)
+			if dm.ProjectDMID == assignment.ProjectDMID {
+				yourScores[key] = sc.ScoreValue
+			}
+		}
+
+		weights, err := s.directWtRepo.GetDIrectWeightls(dm.ProjectDMID, previous)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range weights {
+			weightValues[w.CriteriaID] = append(weightValues[w.CriteriaID], w.WeightValue)
+			if dm.ProjectDMID == assignment.ProjectDMID {
+				yourWeights[w.CriteriaID] = w.WeightValue
+			}
+		}
+	}
+
+	for key, values := range cellValues {
+		mean, stdDev := calculations.MeanStdDev(values)
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		item := models.RoundFeedbackScoreDTO{
+			AlternativeID: key.alternativeID,
+			CriteriaID:    key.criteriaID,
+			GroupMean:     mean,
+			GroupMedian:   calculations.Median(values),
+			GroupMin:      sorted[0],
+			GroupMax:      sorted[len(sorted)-1],
+			GroupStdDev:   stdDev,
+		}
+		if yours, ok := yourScores[key]; ok {
+			item.YourScore = &yours
+		}
+		feedback.Scores = append(feedback.Scores, item)
+	}
+	sort.Slice(feedback.Scores, func(i, j int) bool {
+		if feedback.Scores[i].AlternativeID != feedback.Scores[j].AlternativeID {
+			return feedback.Scores[i].AlternativeID < feedback.Scores[j].AlternativeID
+		}
+		return feedback.Scores[i].CriteriaID < feedback.Scores[j].CriteriaID
+	})
+
+	for criteriaID, values := range weightValues {
+		mean, stdDev := calculations.MeanStdDev(values)
+		item := models.RoundFeedbackWeightDTO{CriteriaID: criteriaID, GroupMean: mean, GroupStdDev: stdDev}
+		if yours, ok := yourWeights[criteriaID]; ok {
+			item.YourWeight = &yours
+		}
+		feedback.Weights = append(feedback.Weights, item)
+	}
+	sort.Slice(feedback.Weights, func(i, j int) bool {
+		return feedback.Weights[i].CriteriaID < feedback.Weights[j].CriteriaID
+	})
+
+	return feedback, nil
+}